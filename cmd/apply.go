@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:         "apply <plan>",
+	Short:       "Apply an integration plan saved with --plan-out",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plan, err := install.ReadPlan(args[0])
+		if err != nil {
+			return errors.Wrap(err, "could not read plan")
+		}
+
+		plan.PrintSummary(os.Stdout)
+
+		fmt.Printf("Applying plan to %s...\n", plan.PluginsDir)
+
+		result, err := plan.Apply()
+		if err != nil {
+			return errors.Wrap(err, "could not apply plan")
+		}
+
+		result.PrintSummary(os.Stdout)
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var compatCmd = &cobra.Command{
+	Use:   "compat",
+	Short: "Print the Unreal Engine versions supported by each Wwise UE integration version",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		wwiseClient, ok := ClientFromContext(cmd.Context())
+		if !ok {
+			return errors.New("could not get Wwise client from context")
+		}
+
+		matrix, err := wwise.UnrealCompatibilityMatrix(wwiseClient)
+		if err != nil {
+			return errors.Wrap(err, "could not get compatibility matrix")
+		}
+
+		var engineVersions []product.SupportedUnrealVersions
+		seen := make(map[product.SupportedUnrealVersions]bool)
+		for _, integration := range matrix {
+			for _, engineVersion := range integration.EngineVersions {
+				if !seen[engineVersion] {
+					seen[engineVersion] = true
+					engineVersions = append(engineVersions, engineVersion)
+				}
+			}
+		}
+		sort.Slice(engineVersions, func(i, j int) bool {
+			if engineVersions[i].Major != engineVersions[j].Major {
+				return engineVersions[i].Major < engineVersions[j].Major
+			}
+			return engineVersions[i].Minor < engineVersions[j].Minor
+		})
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := []string{"INTEGRATION"}
+		for _, engineVersion := range engineVersions {
+			header = append(header, fmt.Sprintf("%d.%d", engineVersion.Major, engineVersion.Minor))
+		}
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+		for _, integration := range matrix {
+			row := []string{integration.IntegrationVersion}
+			for _, engineVersion := range engineVersions {
+				if integration.Supports(engineVersion.Major, engineVersion.Minor) {
+					row = append(row, "x")
+				} else {
+					row = append(row, "-")
+				}
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compatCmd)
+}
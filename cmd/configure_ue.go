@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var configureUECmd = &cobra.Command{
+	Use:         "configure-ue",
+	Short:       "Write the Wwise settings to the config files of an Unreal Engine project",
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, target, _, err := integratorFromFlags(cmd, "unreal")
+		if err != nil {
+			return err
+		}
+
+		changedFiles, err := wwise.ConfigureWwiseUnreal(target, wwise.UnrealSettings{
+			WwiseProject:  viper.GetString("wwise-project"),
+			SoundBanksDir: viper.GetString("soundbanks-dir"),
+			Platforms:     viper.GetStringSlice("settings-platforms"),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not configure Wwise")
+		}
+
+		if len(changedFiles) == 0 {
+			fmt.Println("Wwise settings are up to date")
+		}
+		for _, changedFile := range changedFiles {
+			fmt.Printf("Wrote the Wwise settings to %s\n", changedFile)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configureUECmd)
+
+	addIntegratorFlags(configureUECmd, "unreal", "Unreal Engine project to configure", true)
+	configureUECmd.MarkFlagRequired("project")
+	configureUECmd.Flags().String("wwise-project", "", "Wwise project (.wproj) of the game")
+	configureUECmd.MarkFlagRequired("wwise-project")
+	configureUECmd.Flags().String("soundbanks-dir", "", "Generated sound banks directory (default GeneratedSoundBanks next to the .wproj)")
+	configureUECmd.Flags().StringSlice("settings-platforms", []string{}, "UE platforms to write the Wwise initialization settings for, e.g. Win64,Android")
+}
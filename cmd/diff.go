@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func getVersionInfo(wwiseProduct *product.WwiseProduct, version string) (*product.WwiseProductVersion, product.ProductVersionInfo, error) {
+	productVersion, err := wwiseProduct.GetVersion(version)
+	if err != nil {
+		return nil, product.ProductVersionInfo{}, errors.Wrapf(err, "could not get %s version %s", wwiseProduct.ProductName, version)
+	}
+
+	versionInfo, err := productVersion.GetInfo()
+	if err != nil {
+		return nil, product.ProductVersionInfo{}, errors.Wrapf(err, "could not get %s version %s info", wwiseProduct.ProductName, version)
+	}
+	return productVersion, versionInfo, nil
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <product> <from-version> <to-version>",
+	Short: "Compare two versions of a Wwise product",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productName, fromVersion, toVersion := args[0], args[1], args[2]
+		content := viper.GetBool("content")
+		format := viper.GetString("format")
+		if format != "text" && format != "json" {
+			return errors.Errorf("unknown format %s, expected text or json", format)
+		}
+
+		wwiseClient, ok := ClientFromContext(cmd.Context())
+		if !ok {
+			return errors.New("could not get Wwise client from context")
+		}
+
+		wwiseProduct := product.NewWwiseProduct(wwiseClient, productName)
+		if content {
+			// Checked before getting the versions, as that creates their cache directories
+			for _, version := range []string{fromVersion, toVersion} {
+				if !wwiseProduct.IsCached(version) {
+					return errors.Errorf("%s %s is not downloaded, download it to compare its content", productName, version)
+				}
+			}
+		}
+
+		from, fromInfo, err := getVersionInfo(wwiseProduct, fromVersion)
+		if err != nil {
+			return err
+		}
+		to, toInfo, err := getVersionInfo(wwiseProduct, toVersion)
+		if err != nil {
+			return err
+		}
+
+		diff := product.DiffVersionInfo(fromInfo, toInfo)
+
+		var contentDiff *product.ContentDiff
+		if content {
+			contentDiff, err = product.DiffContent(from, to)
+			if err != nil {
+				return errors.Wrap(err, "could not compare downloaded content")
+			}
+		}
+
+		if format == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(struct {
+				product.VersionDiff
+				Content *product.ContentDiff `json:"content,omitempty"`
+			}{diff, contentDiff}); err != nil {
+				return errors.Wrap(err, "could not write diff")
+			}
+			return nil
+		}
+
+		diff.PrintSummary(os.Stdout)
+		if contentDiff != nil {
+			for _, group := range []string{"Packages", "DeploymentPlatforms"} {
+				if !sameValues(from.DownloadedGroupValues(group), to.DownloadedGroupValues(group)) {
+					fmt.Printf("\nWarning: different %s were downloaded for the two versions, the content diff includes them\n", group)
+				}
+			}
+			contentDiff.PrintSummary(os.Stdout)
+		}
+		return nil
+	},
+}
+
+func sameValues(a []string, b []string) bool {
+	set := make(map[string]bool)
+	for _, value := range a {
+		set[value] = true
+	}
+	for _, value := range b {
+		if !set[value] {
+			return false
+		}
+		delete(set, value)
+	}
+	return len(set) == 0
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().Bool("content", false, "Also compare the downloaded files of both versions")
+	diffCmd.Flags().String("format", "text", "Output format: text or json")
+}
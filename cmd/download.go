@@ -10,23 +10,54 @@ import (
 	"github.com/spf13/viper"
 )
 
+func parseFilters(filters []string) ([]product.GroupFilter, error) {
+	filterMap := make(map[string][]string)
+	var keys []string
+	for _, filter := range filters {
+		parts := strings.Split(filter, "=")
+		if len(parts) == 1 {
+			parts = append(parts, "")
+		}
+		if len(parts) != 2 {
+			return nil, errors.New("invalid filter format. use key=value")
+		}
+		if _, ok := filterMap[parts[0]]; !ok {
+			keys = append(keys, parts[0])
+		}
+		filterMap[parts[0]] = append(filterMap[parts[0]], parts[1])
+	}
+
+	groupFilter := make([]product.GroupFilter, 0)
+	for _, key := range keys {
+		groupFilter = append(groupFilter, product.GroupFilter{GroupID: key, GroupValues: filterMap[key]})
+	}
+	return groupFilter, nil
+}
+
 var downloadCmd = &cobra.Command{
 	Use:   "download",
-	Short: "Download a version of wwise",
+	Short: "Download a version of a Wwise product",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		sdkVersion := viper.GetString("sdk-version")
+		productName := viper.GetString("product")
+		version := viper.GetString("version")
+		if version == "" {
+			version = viper.GetString("sdk-version")
+		}
+		if version == "" {
+			return errors.New("required flag \"version\" not set")
+		}
 
-		filters := viper.GetStringSlice("filter")
-		filterMap := make(map[string][]string)
-		for _, filter := range filters {
-			parts := strings.Split(filter, "=")
-			if len(parts) == 1 {
-				parts = append(parts, "")
-			}
-			if len(parts) != 2 {
-				return errors.New("invalid filter format. use key=value")
+		groupFilter, err := parseFilters(viper.GetStringSlice("filter"))
+		if err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("filter") {
+			category, ok := product.FindCategory(productName)
+			if !ok || len(category.DefaultFilters) == 0 {
+				// Without a filter every file of every platform would be downloaded
+				return errors.Errorf("%s has no default filters, choose the files to download with --filter", productName)
 			}
-			filterMap[parts[0]] = append(filterMap[parts[0]], parts[1])
+			groupFilter = category.DefaultFilters
 		}
 
 		wwiseClient, ok := ClientFromContext(cmd.Context())
@@ -34,29 +65,24 @@ var downloadCmd = &cobra.Command{
 			return errors.New("could not get Wwise client from context")
 		}
 
-		fmt.Printf("Downloading Wwise sdk %s...\n", sdkVersion)
+		fmt.Printf("Downloading %s %s...\n", productName, version)
 
-		sdk := product.NewWwiseProduct(wwiseClient, "wwise")
-		sdkProductVersion, err := sdk.GetVersion(sdkVersion)
+		wwiseProduct := product.NewWwiseProduct(wwiseClient, productName)
+		productVersion, err := wwiseProduct.GetVersion(version)
 		if err != nil {
-			return errors.Wrap(err, "could not get SDK version")
+			return errors.Wrap(err, "could not get product version")
 		}
 
-		sdkVersionInfo, err := sdkProductVersion.GetInfo()
+		versionInfo, err := productVersion.GetInfo()
 		if err != nil {
-			return errors.Wrap(err, "could not get SDK version info")
+			return errors.Wrap(err, "could not get product version info")
 		}
 
-		groupFilter := make([]product.GroupFilter, 0)
-		for key, values := range filterMap {
-			groupFilter = append(groupFilter, product.GroupFilter{GroupID: key, GroupValues: values})
-		}
-
-		files := sdkVersionInfo.FindFilesByGroups(groupFilter)
+		files := versionInfo.FindFilesByGroups(groupFilter)
 
 		for _, file := range files {
 			fmt.Printf("Downloading %v from %v\n", file.Name, file.URL)
-			err = sdkProductVersion.DownloadOrCache(file)
+			err = productVersion.DownloadOrCache(file)
 			if err != nil {
 				return errors.Wrapf(err, "could not download file %v", file.Name)
 			}
@@ -69,10 +95,9 @@ var downloadCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(downloadCmd)
 
+	downloadCmd.Flags().String("product", "wwise", "Product category to download (see the products command)")
+	downloadCmd.Flags().String("version", "", "Product version to download")
 	downloadCmd.Flags().String("sdk-version", "", "Wwise SDK version to download")
-	downloadCmd.MarkFlagRequired("sdk-version")
-	downloadCmd.Flags().StringArray("filter", []string{"Packages=SDK"}, "Filters to apply to the downloaded files")
-
-	_ = viper.BindPFlag("sdk-version", downloadCmd.Flags().Lookup("sdk-version"))
-	_ = viper.BindPFlag("filter", downloadCmd.Flags().Lookup("filter"))
+	_ = downloadCmd.Flags().MarkDeprecated("sdk-version", "use --version instead")
+	downloadCmd.Flags().StringArray("filter", []string{}, "Filters to apply to the downloaded files (defaults to the product's default filters, required for other products)")
 }
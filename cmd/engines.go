@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var enginesCmd = &cobra.Command{
+	Use:         "engines",
+	Short:       "List and register Unreal Engine installations",
+	Annotations: map[string]string{offlineAnnotation: "true"},
+}
+
+var enginesListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List the registered Unreal Engine installations",
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		engines, err := unrealengine.ListEngines()
+		if err != nil {
+			return errors.Wrap(err, "could not list engines")
+		}
+
+		identifiers := make([]string, 0, len(engines))
+		for identifier := range engines {
+			identifiers = append(identifiers, identifier)
+		}
+		sort.Strings(identifiers)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ASSOCIATION\tROOT\tVERSION\tCHANGELIST\tBRANCH\tLICENSEE\tDEPLOYMENT PLATFORM")
+		for _, identifier := range identifiers {
+			engineRoot := engines[identifier]
+
+			version, changelist, branch, licensee, deploymentPlatform := "unknown", "-", "-", "-", "-"
+			if engineVersion, err := unrealengine.GetEngineVersion(engineRoot); err == nil {
+				version = engineVersion.String()
+				if value, err := wwise.UnrealDeploymentPlatform(engineVersion, nil); err == nil {
+					deploymentPlatform = value
+				}
+			}
+			if build, err := unrealengine.GetEngineVersionData(engineRoot); err == nil {
+				changelist = strconv.Itoa(build.Changelist)
+				branch = build.BranchName
+				licensee = strconv.FormatBool(build.IsLicenseeVersion != 0)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", identifier, engineRoot, version, changelist, branch, licensee, deploymentPlatform)
+		}
+		return w.Flush()
+	},
+}
+
+var enginesRegisterCmd = &cobra.Command{
+	Use:         "register <association> <engine root>",
+	Short:       "Register an Unreal Engine installation, so projects can be associated with it",
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := unrealengine.RegisterEngine(args[0], args[1]); err != nil {
+			return errors.Wrap(err, "could not register engine")
+		}
+
+		fmt.Printf("Registered %s as %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+var enginesUnregisterCmd = &cobra.Command{
+	Use:         "unregister <association>",
+	Short:       "Remove the registration of an Unreal Engine installation",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := unrealengine.UnregisterEngine(args[0])
+		if err != nil {
+			return errors.Wrap(err, "could not unregister engine")
+		}
+		if !removed {
+			return errors.Errorf("no engine is registered as %s", args[0])
+		}
+
+		fmt.Printf("Unregistered %s\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(enginesCmd)
+
+	enginesCmd.AddCommand(enginesListCmd)
+	enginesCmd.AddCommand(enginesRegisterCmd)
+	enginesCmd.AddCommand(enginesUnregisterCmd)
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// The integration commands work with the integrator of their engine, or with the one selected with --engine
+// if their engine is empty. The commands of an engine are the generic ones with the engine set.
+
+// integratorsFor returns the integrator of the engine, or every registered integrator if the engine is empty.
+func integratorsFor(engine string) []wwise.Integrator {
+	names := wwise.IntegratorNames()
+	if engine != "" {
+		names = []string{engine}
+	}
+	var integrators []wwise.Integrator
+	for _, name := range names {
+		if integrator, ok := wwise.LookupIntegrator(name); ok {
+			integrators = append(integrators, integrator)
+		}
+	}
+	return integrators
+}
+
+// addIntegratorFlags adds the flags selecting the integrator, the project and the settings of the integrators,
+// only the target settings if targetOnly is set.
+func addIntegratorFlags(cmd *cobra.Command, engine string, projectUsage string, targetOnly bool) {
+	if engine == "" {
+		cmd.Flags().String("engine", "unreal", "Engine integration to use: "+strings.Join(wwise.IntegratorNames(), ", "))
+	}
+	cmd.Flags().String("project", "", projectUsage)
+	addSettingFlags(cmd, integratorsFor(engine), targetOnly)
+}
+
+// integratorFromFlags returns the integrator of the engine, or the one selected with --engine if the engine is empty,
+// the target it detects from --project, and the settings set with the flags.
+func integratorFromFlags(cmd *cobra.Command, engine string) (wwise.Integrator, wwise.IntegrationTarget, map[string]string, error) {
+	if engine == "" {
+		engine = viper.GetString("engine")
+	}
+	integrator, ok := wwise.LookupIntegrator(engine)
+	if !ok {
+		return nil, nil, nil, errors.Errorf("unknown engine %s, available engines: %s", engine, strings.Join(wwise.IntegratorNames(), ", "))
+	}
+
+	settings := settingsFromFlags(cmd, integrator)
+	target, err := integrator.DetectProject(viper.GetString("project"), settings)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not detect project")
+	}
+	return integrator, target, settings, nil
+}
+
+func newIntegrateCmd(use string, engine string, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			integrationVersion := viper.GetString("integration-version")
+
+			integrator, target, settings, err := integratorFromFlags(cmd, engine)
+			if err != nil {
+				return err
+			}
+
+			link, err := install.ParseLinkMode(viper.GetString("link"))
+			if err != nil {
+				return err
+			}
+			options := wwise.IntegrationOptions{
+				Platforms:      viper.GetStringSlice("platforms"),
+				Configurations: viper.GetStringSlice("configurations"),
+				Plugins:        viper.GetStringSlice("plugins"),
+				Link:           link,
+				Settings:       settings,
+			}
+
+			// Keep the selection recorded by the previous integration unless it is overridden
+			installed, err := integrator.Installed(target)
+			if err != nil {
+				return errors.Wrap(err, "could not detect installed integration")
+			}
+			if installed != nil {
+				if !cmd.Flags().Changed("link") && installed.Link != "" {
+					options.Link = installed.Link
+				}
+				if !cmd.Flags().Changed("platforms") {
+					options.Platforms = installed.Platforms
+				}
+				if !cmd.Flags().Changed("configurations") {
+					options.Configurations = installed.Configurations
+				}
+				if !cmd.Flags().Changed("plugins") {
+					options.Plugins = installed.Plugins
+				}
+			}
+
+			wwiseClient, ok := ClientFromContext(cmd.Context())
+			if !ok {
+				return errors.New("could not get Wwise client from context")
+			}
+
+			engineVersion, err := integrator.EngineVersion(target)
+			if err != nil {
+				return errors.Wrap(err, "could not get engine version")
+			}
+			fmt.Printf("Integrating Wwise %s to %s %s in %s...\n", integrationVersion, integrator.Name(), engineVersion, target.PluginsDir())
+
+			plan, err := wwise.PlanIntegration(integrator, target, integrationVersion, options, wwiseClient)
+			if err != nil {
+				return errors.Wrap(err, "could not plan Wwise integration")
+			}
+
+			plan.PrintSummary(os.Stdout)
+
+			planOut := viper.GetString("plan-out")
+			if viper.GetBool("plan") || planOut != "" {
+				if planOut != "" {
+					if err := plan.Save(planOut); err != nil {
+						return errors.Wrap(err, "could not save plan")
+					}
+					fmt.Printf("Saved plan to %s\n", planOut)
+				}
+				return nil
+			}
+
+			result, err := plan.Apply()
+			if err != nil {
+				return errors.Wrap(err, "could not integrate Wwise")
+			}
+
+			result.PrintSummary(os.Stdout)
+
+			return nil
+		},
+	}
+
+	addIntegratorFlags(cmd, engine, "Project to integrate Wwise to", false)
+	cmd.Flags().String("integration-version", "", "Wwise integration version to download")
+	cmd.MarkFlagRequired("integration-version")
+	cmd.Flags().StringSlice("platforms", []string{}, "Platforms to integrate the Wwise SDK for (defaults to the previous selection, or all)")
+	cmd.Flags().StringSlice("configurations", []string{}, "SDK configurations to integrate: Debug, Profile, Release (defaults to the previous selection, or all)")
+	cmd.Flags().StringSlice("plugins", []string{}, "Extra SDK plugins to download and integrate (defaults to the previous selection, or none)")
+	cmd.Flags().String("link", string(install.LinkCopy), "How to put the SDK files in place: copy, or symlink, hardlink or reflink to the download cache, which must then be kept (defaults to the previous choice, or copy)")
+	cmd.Flags().Bool("plan", false, "Only print the file operations the integration would perform")
+	cmd.Flags().String("plan-out", "", "Save the plan as JSON to this file, to be executed later with the apply command, instead of integrating")
+
+	return cmd
+}
+
+func newVerifyCmd(use string, engine string, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  short + ". Exits with a non-zero status if any file is missing, modified or unexpected, or a project file is not set up for the integration.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			integrator, target, _, err := integratorFromFlags(cmd, engine)
+			if err != nil {
+				return err
+			}
+
+			wwiseClient, ok := ClientFromContext(cmd.Context())
+			if !ok {
+				return errors.New("could not get Wwise client from context")
+			}
+
+			fmt.Printf("Verifying Wwise in %s...\n", target.PluginsDir())
+
+			verification, err := integrator.Verify(target, viper.GetString("integration-version"), wwiseClient)
+			if err != nil {
+				return errors.Wrap(err, "could not verify Wwise integration")
+			}
+
+			verification.PrintSummary(os.Stdout)
+
+			if !verification.OK() {
+				cmd.SilenceUsage = true
+				return errors.New("installation does not match")
+			}
+
+			return nil
+		},
+	}
+
+	addIntegratorFlags(cmd, engine, "Project to verify", true)
+	cmd.Flags().String("integration-version", "", "Wwise integration version to verify against (defaults to the installed version)")
+
+	return cmd
+}
+
+func newUninstallCmd(use string, engine string, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         use,
+		Short:       short,
+		Annotations: map[string]string{offlineAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			integrator, target, _, err := integratorFromFlags(cmd, engine)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Removing Wwise from %s...\n", target.PluginsDir())
+
+			result, err := integrator.Uninstall(target, viper.GetBool("force"))
+			if err != nil {
+				return errors.Wrap(err, "could not uninstall Wwise")
+			}
+
+			result.PrintSummary(os.Stdout)
+
+			return nil
+		},
+	}
+
+	addIntegratorFlags(cmd, engine, "Project to remove Wwise from", true)
+	cmd.Flags().Bool("force", false, "Also remove modified files and files added to the integration")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(newIntegrateCmd("integrate", "", "Integrate a version of wwise to a project of any registered engine integration"))
+	rootCmd.AddCommand(newIntegrateCmd("integrate-ue", "unreal", "Integrate a version of wwise to an Unreal Engine project or engine"))
+	rootCmd.AddCommand(newIntegrateCmd("integrate-unity", "unity", "Integrate a version of wwise to a Unity project"))
+
+	rootCmd.AddCommand(newVerifyCmd("verify", "", "Check that the Wwise integration of a project of any registered engine integration matches what its version installs"))
+	rootCmd.AddCommand(newVerifyCmd("verify-ue", "unreal", "Check that the Wwise integration of an Unreal Engine project or engine matches what its version installs"))
+
+	rootCmd.AddCommand(newUninstallCmd("uninstall", "", "Remove the Wwise integration from a project of any registered engine integration"))
+	rootCmd.AddCommand(newUninstallCmd("uninstall-ue", "unreal", "Remove the Wwise integration from an Unreal Engine project or engine"))
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Report the Wwise integration versions a project can be upgraded to",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := viper.GetString("format")
+		if format != "markdown" && format != "json" {
+			return errors.Errorf("unknown format %s, expected markdown or json", format)
+		}
+
+		integrator, target, _, err := integratorFromFlags(cmd, "")
+		if err != nil {
+			return err
+		}
+		checker, ok := integrator.(wwise.UpgradeChecker)
+		if !ok {
+			return errors.Errorf("checking for upgrades is not supported for %s projects", integrator.Name())
+		}
+
+		wwiseClient, ok := ClientFromContext(cmd.Context())
+		if !ok {
+			return errors.New("could not get Wwise client from context")
+		}
+
+		report, err := checker.CheckOutdated(target, wwiseClient)
+		if err != nil {
+			return errors.Wrap(err, "could not check for upgrades")
+		}
+
+		var w io.Writer = os.Stdout
+		if output := viper.GetString("output"); output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return errors.Wrap(err, "could not create report file")
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if format == "json" {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				return errors.Wrap(err, "could not write report")
+			}
+			return nil
+		}
+
+		report.WriteMarkdown(w)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+
+	addIntegratorFlags(outdatedCmd, "", "Project to check", true)
+	outdatedCmd.Flags().String("format", "markdown", "Report format: markdown or json")
+	outdatedCmd.Flags().String("output", "", "Write the report to this file instead of the standard output")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var productsCmd = &cobra.Command{
+	Use:   "products",
+	Short: "List the product categories that can be downloaded",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var names []string
+		if viper.GetBool("offline") {
+			fmt.Println("Categories with default filters. Any other category offered by the API can be downloaded with --product and --filter.")
+		} else {
+			wwiseClient, ok := ClientFromContext(cmd.Context())
+			if !ok {
+				return errors.New("could not get Wwise client from context")
+			}
+
+			var err error
+			names, err = product.ListCategories(wwiseClient)
+			if err != nil {
+				return errors.Wrap(err, "could not list product categories")
+			}
+		}
+
+		// Categories with default filters come first, even if the API does not list them
+		listed := make(map[string]bool)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "CATEGORY\tDESCRIPTION\tDEFAULT FILTERS")
+		for _, category := range product.Categories {
+			listed[category.Name] = true
+			var filters []string
+			for _, filter := range category.DefaultFilters {
+				for _, value := range filter.GroupValues {
+					filters = append(filters, filter.GroupID+"="+value)
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", category.Name, category.Description, strings.Join(filters, ", "))
+		}
+		for _, name := range names {
+			if !listed[name] {
+				fmt.Fprintf(w, "%s\t-\t-\n", name)
+			}
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(productsCmd)
+
+	productsCmd.Flags().Bool("offline", false, "Only list the categories wwise-cli has default filters for, without querying the Wwise API")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:         "rollback",
+	Short:       "Undo the last Wwise integration to, or uninstallation from, a project",
+	Long:        "Undo the last Wwise integration to, or uninstallation from, a project. Only the last one can be undone: its backup is kept in the installs directory of the cache directory until the next integration or uninstallation replaces it.",
+	Annotations: map[string]string{offlineAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, target, _, err := integratorFromFlags(cmd, "")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Rolling back the last Wwise integration...")
+
+		if err := wwise.Rollback(target); err != nil {
+			return errors.Wrap(err, "could not roll back Wwise integration")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+
+	addIntegratorFlags(rollbackCmd, "", "Project to roll back", true)
+}
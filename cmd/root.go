@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/mircearoata/wwise-cli/lib/wwise"
 	"github.com/mircearoata/wwise-cli/lib/wwise/client"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -13,11 +14,27 @@ import (
 	"golang.org/x/term"
 )
 
+// Commands annotated with offlineAnnotation do not talk to the Wwise API, so they skip authentication.
+// Commands that only use the API for part of their work have an offline flag to skip it instead.
+const offlineAnnotation = "offline"
+
 var rootCmd = &cobra.Command{
 	Use: "wwise-cli",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Flags are bound when the command runs, as several commands share flag names, and the keys have to point to
+		// the flags of the command that runs. The flags of the command include the persistent flags of the root command.
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			return err
+		}
+
 		viper.SetEnvPrefix("wwise")
 		viper.AutomaticEnv()
+		if cmd.Annotations[offlineAnnotation] == "true" {
+			return nil
+		}
+		if cmd.Flags().Lookup("offline") != nil && viper.GetBool("offline") {
+			return nil
+		}
 		if !viper.IsSet("email") {
 			fmt.Print("Enter Wwise email: ")
 			scanner := bufio.NewScanner(os.Stdin)
@@ -69,7 +86,5 @@ func init() {
 	cacheDir := filepath.Join(userCache, "wwise-cli")
 	rootCmd.PersistentFlags().String("cache-dir", cacheDir, "Cache directory")
 
-	_ = viper.BindPFlag("email", rootCmd.PersistentFlags().Lookup("email"))
-	_ = viper.BindPFlag("password", rootCmd.PersistentFlags().Lookup("password"))
-	_ = viper.BindPFlag("cache-dir", rootCmd.PersistentFlags().Lookup("cache-dir"))
+	rootCmd.PersistentFlags().String("deployment-platforms", wwise.DefaultDeploymentPlatformsFile(), "YAML file mapping Unreal Engine versions (major.minor) to the DeploymentPlatforms value of their integration packages")
 }
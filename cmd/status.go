@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the Wwise integration of a project",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		integrator, target, _, err := integratorFromFlags(cmd, "")
+		if err != nil {
+			return err
+		}
+
+		if unrealTarget, ok := target.(wwise.UnrealTarget); ok {
+			return printUnrealStatus(cmd, unrealTarget)
+		}
+		return printStatus(integrator, target)
+	},
+}
+
+// printStatus shows what every integrator knows of the integration of the target.
+func printStatus(integrator wwise.Integrator, target wwise.IntegrationTarget) error {
+	engineVersion, err := integrator.EngineVersion(target)
+	if err != nil {
+		fmt.Printf("Engine:             %s, unknown version (%s)\n", integrator.Name(), err)
+	} else {
+		fmt.Printf("Engine:             %s %s\n", integrator.Name(), engineVersion)
+	}
+
+	installed, err := integrator.Installed(target)
+	if err != nil {
+		return errors.Wrap(err, "could not detect installed integration")
+	}
+	if installed == nil {
+		fmt.Printf("Wwise:              not installed in %s\n", target.PluginsDir())
+		return nil
+	}
+
+	managed, err := install.ReadManifest(target.PluginsDir())
+	if err != nil {
+		return errors.Wrap(err, "could not read install manifest")
+	}
+	managedBy := "installed by wwise-cli"
+	if managed == nil {
+		managedBy = "not installed by wwise-cli"
+	}
+	fmt.Printf("Integration:        %s in %s (%s)\n", installed.IntegrationVersion, target.PluginsDir(), managedBy)
+	if installed.SdkVersion != "" {
+		fmt.Printf("SDK:                %s\n", installed.SdkVersion)
+	}
+	if len(installed.Platforms) > 0 {
+		fmt.Printf("Platforms:          %s\n", strings.Join(installed.Platforms, ", "))
+	}
+	if installed.Link != "" {
+		fmt.Printf("Link:               %s\n", installed.Link)
+	}
+	return nil
+}
+
+func printUnrealStatus(cmd *cobra.Command, target wwise.UnrealTarget) error {
+	status, err := wwise.GetUnrealStatus(target)
+	if err != nil {
+		return errors.Wrap(err, "could not get status")
+	}
+
+	if !target.IsEngine() {
+		fmt.Printf("Project:            %s\n", target.Project)
+		fmt.Printf("Engine association: %s\n", status.EngineAssociation)
+	}
+	if status.EngineError != "" {
+		fmt.Printf("Engine:             unknown (%s)\n", status.EngineError)
+	} else {
+		fmt.Printf("Engine:             %s\n", status.EngineRoot)
+		fmt.Printf("Engine version:     %s (from %s)\n", status.EngineVersion, status.EngineVersion.Source)
+	}
+
+	if status.Installed == nil {
+		fmt.Printf("Wwise:              not installed in %s\n", target.PluginsDir())
+		return nil
+	}
+
+	managedBy := "installed by wwise-cli"
+	if !status.Managed {
+		managedBy = "not installed by wwise-cli"
+	}
+	fmt.Printf("Integration:        %s (%s)\n", status.Installed.IntegrationVersion, managedBy)
+	fmt.Printf("SDK:                %s\n", status.SdkVersion)
+	fmt.Printf("SDK platforms:      %s\n", strings.Join(status.ThirdPartyPlatforms, ", "))
+	if len(status.Installed.Configurations) > 0 {
+		fmt.Printf("Configurations:     %s\n", strings.Join(status.Installed.Configurations, ", "))
+	}
+	if status.Installed.Link != "" {
+		fmt.Printf("Link:               %s\n", status.Installed.Link)
+	}
+
+	if !target.IsEngine() {
+		for _, plugin := range status.Plugins {
+			state := "not listed in the project file"
+			if plugin.Listed {
+				state = "disabled"
+				if plugin.Enabled {
+					state = "enabled"
+				}
+			}
+			fmt.Printf("Plugin %-12s %s\n", plugin.Name+":", state)
+		}
+	}
+
+	fmt.Printf("Cached for offline reinstall: integration %s, SDK %s\n", yesNo(status.CachedIntegration), yesNo(status.CachedSdk))
+
+	if viper.GetBool("offline") || status.EngineVersion == nil {
+		return nil
+	}
+
+	wwiseClient, ok := ClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("could not get Wwise client from context")
+	}
+
+	newer, err := wwise.NewerUnrealIntegrations(status.Installed.IntegrationVersion, *status.EngineVersion, wwiseClient)
+	if errors.Cause(err) == wwise.ErrUnknownIntegrationVersion {
+		fmt.Printf("Newer versions:     unknown (%s)\n", err)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not check for newer integration versions")
+	}
+	if len(newer) == 0 {
+		fmt.Println("Newer versions:     none")
+	} else {
+		var versions []string
+		for _, integration := range newer {
+			versions = append(versions, integration.IntegrationVersion)
+		}
+		fmt.Printf("Newer versions:     %s\n", strings.Join(versions, ", "))
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	addIntegratorFlags(statusCmd, "", "Project to show", true)
+	statusCmd.Flags().Bool("offline", false, "Do not check for newer integration versions, which needs to log in")
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mircearoata/wwise-cli/lib/manifest"
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Download and integrate the Wwise versions declared in a project manifest",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifestPath := viper.GetString("manifest")
+
+		m, err := manifest.Load(manifestPath)
+		if err != nil {
+			return errors.Wrap(err, "could not load manifest")
+		}
+
+		wwiseClient, ok := ClientFromContext(cmd.Context())
+		if !ok {
+			return errors.New("could not get Wwise client from context")
+		}
+
+		fmt.Printf("Syncing Wwise %s to %s project...\n", m.IntegrationVersion, m.EngineName())
+
+		var settings map[string]string
+		if integrator, ok := wwise.LookupIntegrator(m.EngineName()); ok {
+			settings = settingsFromFlags(cmd, integrator)
+		}
+
+		upToDate, result, err := wwise.Sync(m, settings, wwiseClient)
+		if err != nil {
+			return errors.Wrap(err, "could not sync Wwise")
+		}
+
+		if upToDate {
+			fmt.Println("Wwise is up to date")
+		} else {
+			result.PrintSummary(os.Stdout)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().String("manifest", manifest.DefaultFileName, "Project manifest declaring the Wwise versions to use")
+	addSettingFlags(syncCmd, integratorsFor(""), true)
+}
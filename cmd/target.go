@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/mircearoata/wwise-cli/lib/wwise"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	// The engine root can also be overridden for every command at once, e.g. on build machines
+	_ = viper.BindEnv("engine-root", "WWISE_UE_ENGINE_ROOT")
+}
+
+// addSettingFlags adds a flag for each setting of the integrators, only for the target settings if targetOnly is set.
+// Integrators sharing a setting share its flag.
+func addSettingFlags(cmd *cobra.Command, integrators []wwise.Integrator, targetOnly bool) {
+	for _, integrator := range integrators {
+		for _, setting := range integrator.Settings() {
+			if (targetOnly && !setting.Target) || cmd.Flags().Lookup(setting.Name) != nil {
+				continue
+			}
+			cmd.Flags().String(setting.Name, setting.Default, setting.Usage)
+		}
+	}
+}
+
+// settingsFromFlags returns the settings of the integrator the command has flags for.
+func settingsFromFlags(cmd *cobra.Command, integrator wwise.Integrator) map[string]string {
+	settings := make(map[string]string)
+	for _, setting := range integrator.Settings() {
+		if cmd.Flags().Lookup(setting.Name) != nil {
+			settings[setting.Name] = viper.GetString(setting.Name)
+		}
+	}
+	return settings
+}
@@ -0,0 +1,305 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Result lists the files whose local modifications were merged, and the files that have conflicts.
+type Result struct {
+	Merged    []string
+	Conflicts []string
+	// Copied is the number of files that were copied because they could not be linked.
+	Copied int
+}
+
+func (r *Result) PrintSummary(w io.Writer) {
+	if r.Copied > 0 {
+		fmt.Fprintf(w, "Copied %d files that could not be linked\n", r.Copied)
+	}
+	if len(r.Merged) > 0 {
+		fmt.Fprintf(w, "Merged local modifications into %d files\n", len(r.Merged))
+	}
+	if len(r.Conflicts) > 0 {
+		fmt.Fprintf(w, "%d files have conflicts, resolve the conflict markers (or the .new file of binary files):\n", len(r.Conflicts))
+		for _, conflict := range r.Conflicts {
+			fmt.Fprintf(w, "  C %s\n", conflict)
+		}
+	}
+}
+
+func pristineDir(pluginsDir string) string {
+	return filepath.Join(DataDir(pluginsDir), "pristine")
+}
+
+// merge merges the local modifications of a file with the changes of the new installation, using the pristine copy
+// of the previous installation as the base. It returns whether the merge has conflicts.
+func (p *Plan) merge(op FileOperation) (bool, error) {
+	dest := filepath.Join(p.PluginsDir, filepath.FromSlash(op.Path))
+
+	local, err := os.ReadFile(dest)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read local file")
+	}
+
+	incoming, err := os.ReadFile(op.Source)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read new file")
+	}
+
+	if !isText(local) {
+		// The local file became binary, keep it and put the new one next to it
+		if err := copyFile(op.Source, dest+".new"); err != nil {
+			return false, errors.Wrap(err, "failed to write new file")
+		}
+		return true, nil
+	}
+
+	// Without a pristine copy every change is a conflict, but the markers still show both versions
+	base, err := os.ReadFile(filepath.Join(pristineDir(p.PluginsDir), filepath.FromSlash(op.Path)))
+	if err != nil && !os.IsNotExist(err) {
+		return false, errors.Wrap(err, "failed to read pristine file")
+	}
+
+	previousLabel := "previous"
+	if p.Previous != nil {
+		previousLabel = p.Previous.IntegrationVersion
+	}
+	merged, conflicts := Merge3(base, local, incoming, [3]string{"local", previousLabel, p.Manifest.IntegrationVersion})
+
+	info, err := os.Stat(dest)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to stat local file")
+	}
+	if err := os.WriteFile(dest, merged, info.Mode().Perm()); err != nil {
+		return false, errors.Wrap(err, "failed to write merged file")
+	}
+
+	return conflicts > 0, nil
+}
+
+// Apply performs exactly the operations of the plan, and records the installed files in the install manifest.
+// Pristine copies of the installed mergeable files are kept in the data directory, to merge local modifications on the next upgrade.
+// Everything is backed up first: if anything fails the original files are restored, and once the plan is applied
+// the backup replaces the one of the previous installation in BackupDir, so that the installation can be rolled back.
+func (p *Plan) Apply() (*Result, error) {
+	tx, err := beginTransaction(p.PluginsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+
+	result, err := p.apply(tx)
+	if err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			return nil, errors.Wrapf(err, "failed to restore the original files (%s)", rollbackErr.Error())
+		}
+		return nil, errors.Wrap(err, "restored the original files")
+	}
+
+	if err := tx.commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return result, nil
+}
+
+func (p *Plan) apply(tx *transaction) (*Result, error) {
+	manifest := p.Manifest
+	manifest.Files = make(map[string]string)
+	manifest.Upstream = make(map[string]string)
+	result := &Result{}
+
+	newPristineDir := pristineDir(p.PluginsDir) + ".new"
+	if err := os.RemoveAll(newPristineDir); err != nil {
+		return nil, errors.Wrap(err, "failed to clean pristine files")
+	}
+	if err := tx.backup(newPristineDir, true); err != nil {
+		return nil, err
+	}
+
+	for _, op := range p.Operations {
+		dest := filepath.Join(p.PluginsDir, filepath.FromSlash(op.Path))
+		switch op.Action {
+		case ActionCreate, ActionOverwrite:
+			sourceHash, err := HashFile(op.Source)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to hash %s", op.Source)
+			}
+			if sourceHash != op.Hash {
+				return nil, errors.New("source file changed since the plan was made: " + op.Source)
+			}
+
+			if err := tx.backup(dest, true); err != nil {
+				return nil, err
+			}
+			// Mergeable files are always copied, as merging local modifications writes to them
+			mode := manifest.Link
+			if op.Mergeable {
+				mode = LinkCopy
+			}
+			usedMode, err := linkFile(op.Source, dest, mode)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to install %s", op.Path)
+			}
+			if usedMode != mode && mode != "" {
+				result.Copied++
+			}
+		case ActionMerge:
+			if err := tx.backup(dest, false); err != nil {
+				return nil, err
+			}
+			if err := tx.backup(dest+".new", true); err != nil {
+				return nil, err
+			}
+			conflict, err := p.merge(op)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to merge %s", op.Path)
+			}
+			if conflict {
+				result.Conflicts = append(result.Conflicts, op.Path)
+			} else {
+				result.Merged = append(result.Merged, op.Path)
+			}
+		case ActionConflict:
+			if err := tx.backup(dest+".new", true); err != nil {
+				return nil, err
+			}
+			if err := copyFile(op.Source, dest+".new"); err != nil {
+				return nil, errors.Wrapf(err, "failed to write new version of %s", op.Path)
+			}
+			result.Conflicts = append(result.Conflicts, op.Path)
+		case ActionDelete:
+			destHash, err := HashFile(dest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to hash %s", dest)
+			}
+			if destHash != op.Hash {
+				return nil, errors.New("file changed since the plan was made: " + dest)
+			}
+			// Moving the file to the backup removes it
+			if err := tx.backup(dest, true); err != nil {
+				return nil, err
+			}
+			removeEmptyParents(p.PluginsDir, dest)
+			continue
+		case ActionUnchanged, ActionKeep:
+		default:
+			continue
+		}
+
+		// Record what the file was left with, and what the installation shipped if that is something else
+		manifest.Files[op.Path] = op.Hash
+		if op.Action == ActionMerge || op.Action == ActionConflict || op.Action == ActionKeep {
+			hash, err := HashFile(dest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to hash %s", dest)
+			}
+			if hash != op.Hash {
+				manifest.Files[op.Path] = hash
+				manifest.Upstream[op.Path] = op.Hash
+			}
+		}
+		if op.Mergeable {
+			if err := copyFile(op.Source, filepath.Join(newPristineDir, filepath.FromSlash(op.Path))); err != nil {
+				return nil, errors.Wrapf(err, "failed to save pristine copy of %s", op.Path)
+			}
+		}
+	}
+
+	if err := tx.backup(pristineDir(p.PluginsDir), true); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(newPristineDir); err == nil {
+		if err := movePath(newPristineDir, pristineDir(p.PluginsDir)); err != nil {
+			return nil, errors.Wrap(err, "failed to save pristine files")
+		}
+	}
+
+	manifest.Edits = nil
+	if p.Previous != nil {
+		manifest.Edits = append(manifest.Edits, p.Previous.Edits...)
+	}
+	for _, edit := range p.Edits {
+		if err := edit.apply(tx); err != nil {
+			return nil, errors.Wrap(err, "failed to edit file")
+		}
+		if len(edit.Changes) > 0 {
+			manifest.Edits = append(manifest.Edits, EditRecord{Path: edit.Path, Changes: edit.Changes})
+		}
+	}
+
+	if err := tx.backup(ManifestPath(p.PluginsDir), false); err != nil {
+		return nil, err
+	}
+	if err := manifest.Save(p.PluginsDir); err != nil {
+		return nil, errors.Wrap(err, "failed to save install manifest")
+	}
+
+	return result, nil
+}
+
+// ApplyEdits applies edits of files outside the installation to pluginsDir on their own, in a transaction that replaces
+// the backup of the last installation. The edits with changes are recorded in the install manifest, if there is one.
+func ApplyEdits(pluginsDir string, edits []FileEdit) error {
+	tx, err := beginTransaction(pluginsDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+
+	if err := applyEdits(tx, pluginsDir, edits); err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			return errors.Wrapf(err, "failed to restore the original files (%s)", rollbackErr.Error())
+		}
+		return errors.Wrap(err, "restored the original files")
+	}
+
+	if err := tx.commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+func applyEdits(tx *transaction, pluginsDir string, edits []FileEdit) error {
+	for _, edit := range edits {
+		if err := edit.apply(tx); err != nil {
+			return errors.Wrap(err, "failed to edit file")
+		}
+	}
+
+	manifest, err := ReadManifest(pluginsDir)
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return nil
+	}
+	for _, edit := range edits {
+		if len(edit.Changes) > 0 {
+			manifest.Edits = append(manifest.Edits, EditRecord{Path: edit.Path, Changes: edit.Changes})
+		}
+	}
+	if err := tx.backup(ManifestPath(pluginsDir), false); err != nil {
+		return err
+	}
+	if err := manifest.Save(pluginsDir); err != nil {
+		return errors.Wrap(err, "failed to save install manifest")
+	}
+	return nil
+}
+
+// removeEmptyParents removes the directories containing file that became empty, up to root.
+// Nothing is removed for files outside root.
+func removeEmptyParents(root string, file string) {
+	if !isInside(root, file) {
+		return
+	}
+	for dir := filepath.Dir(file); dir != root && len(dir) > len(root); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
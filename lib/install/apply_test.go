@@ -0,0 +1,116 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// actions returns the action planned for each file.
+func actions(plan *Plan) map[string]Action {
+	planned := make(map[string]Action)
+	for _, op := range plan.Operations {
+		planned[op.Path] = op.Action
+	}
+	return planned
+}
+
+func TestApplyLocallyModifiedBinary(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/lib.a": "v1\x00"}, true)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/lib.a": "patched\x00"})
+
+	plan, result := install(t, pluginsDir, "2", map[string]string{"Wwise/lib.a": "v2\x00"}, true)
+	if action := actions(plan)["Wwise/lib.a"]; action != ActionConflict {
+		t.Errorf("planned %s for a modified binary, want %s", action, ActionConflict)
+	}
+	if !reflect.DeepEqual(result.Conflicts, []string{"Wwise/lib.a"}) {
+		t.Errorf("conflicts %v, want Wwise/lib.a", result.Conflicts)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/lib.a": "patched\x00", "Wwise/lib.a.new": "v2\x00"})
+
+	// The manifest records the local file, and the version it was made against
+	manifest := mustReadManifest(t, pluginsDir)
+	localHash, err := HashFile(filepath.Join(pluginsDir, "Wwise", "lib.a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Files["Wwise/lib.a"] != localHash {
+		t.Errorf("recorded hash %s, want the hash of the kept file %s", manifest.Files["Wwise/lib.a"], localHash)
+	}
+	newHash, err := HashFile(filepath.Join(pluginsDir, "Wwise", "lib.a.new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Upstream["Wwise/lib.a"] != newHash {
+		t.Errorf("recorded upstream hash %s, want %s", manifest.Upstream["Wwise/lib.a"], newHash)
+	}
+
+	// Verifying reports the file as modified, and the next upgrade does not overwrite it
+	os.Remove(filepath.Join(pluginsDir, "Wwise", "lib.a.new"))
+	plan, err = NewPlan(pluginsDir, sourceFiles(t, map[string]string{"Wwise/lib.a": "v2\x00"}, true), Manifest{IntegrationVersion: "2"}, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification := plan.Verify(); !reflect.DeepEqual(verification.Modified, []string{"Wwise/lib.a"}) {
+		t.Errorf("verification reports %v as modified, want Wwise/lib.a", verification.Modified)
+	}
+	plan, _ = install(t, pluginsDir, "3", map[string]string{"Wwise/lib.a": "v3\x00"}, true)
+	if action := actions(plan)["Wwise/lib.a"]; action != ActionConflict {
+		t.Errorf("planned %s for a modified binary on the next upgrade, want %s", action, ActionConflict)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/lib.a": "patched\x00", "Wwise/lib.a.new": "v3\x00"})
+}
+
+func TestApplyMergeKeepsLocalModificationsAcrossUpgrades(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/a.cpp": "a\nb\nc\nd\n"}, true)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/a.cpp": "a\nb\nc\nd\n// patched\n"})
+
+	plan, result := install(t, pluginsDir, "2", map[string]string{"Wwise/a.cpp": "A\nb\nc\nd\n"}, true)
+	if action := actions(plan)["Wwise/a.cpp"]; action != ActionMerge {
+		t.Errorf("planned %s, want %s", action, ActionMerge)
+	}
+	if !reflect.DeepEqual(result.Merged, []string{"Wwise/a.cpp"}) {
+		t.Errorf("merged %v, want Wwise/a.cpp", result.Merged)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.cpp": "A\nb\nc\nd\n// patched\n"})
+
+	mergedHash, err := HashFile(filepath.Join(pluginsDir, "Wwise", "a.cpp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash := mustReadManifest(t, pluginsDir).Files["Wwise/a.cpp"]; hash != mergedHash {
+		t.Errorf("recorded hash %s, want the hash of the merged file %s", hash, mergedHash)
+	}
+
+	// The merged file still carries the local modifications on the next upgrade
+	plan, _ = install(t, pluginsDir, "3", map[string]string{"Wwise/a.cpp": "A\nB\nc\nd\n"}, true)
+	if action := actions(plan)["Wwise/a.cpp"]; action != ActionMerge {
+		t.Errorf("planned %s on the next upgrade, want %s", action, ActionMerge)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.cpp": "A\nB\nc\nd\n// patched\n"})
+}
+
+func TestApplyMergeConflict(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/a.cpp": "a\nb\n"}, true)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/a.cpp": "a\nlocal\n"})
+
+	_, result := install(t, pluginsDir, "2", map[string]string{"Wwise/a.cpp": "a\nincoming\n"}, true)
+	if !reflect.DeepEqual(result.Conflicts, []string{"Wwise/a.cpp"}) {
+		t.Errorf("conflicts %v, want Wwise/a.cpp", result.Conflicts)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.cpp": "a\n<<<<<<< local\nlocal\n||||||| 1\nb\n=======\nincoming\n>>>>>>> 2\n"})
+
+	// The conflict markers are not taken for the installed content
+	manifest := mustReadManifest(t, pluginsDir)
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, map[string]string{"Wwise/a.cpp": "a\nincoming\n"}, true), Manifest{IntegrationVersion: "2"}, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification := plan.Verify(); verification.OK() {
+		t.Error("verification passes with conflict markers in the file")
+	}
+}
@@ -0,0 +1,80 @@
+package install
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// FileEdit replaces the content of a file outside the installation, such as a project file that has to reference
+// the installed plugins. Edits are applied, and rolled back, together with the installation.
+type FileEdit struct {
+	Path string `json:"path"`
+	// Hash is the hash of the file the edit was made from, or empty if the file did not exist.
+	Hash        string `json:"hash,omitempty"`
+	Content     []byte `json:"content"`
+	Description string `json:"description"`
+	// Changes describe what the edit changes, in the format of the integration that made it, so that uninstalling
+	// can revert exactly that. Applied edits with changes are recorded in the install manifest.
+	Changes json.RawMessage `json:"changes,omitempty"`
+}
+
+// EditRecord is an edit of a file outside the installation, recorded in the install manifest.
+type EditRecord struct {
+	Path    string          `json:"path"`
+	Changes json.RawMessage `json:"changes"`
+}
+
+// NewFileEdit records the new content of the file at path, and the state of the file it was made from.
+func NewFileEdit(path string, content []byte, description string) (FileEdit, error) {
+	edit := FileEdit{
+		Path:        path,
+		Content:     content,
+		Description: description,
+	}
+
+	hash, err := HashFile(path)
+	if err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return FileEdit{}, errors.Wrapf(err, "failed to hash %s", path)
+	}
+	if err == nil {
+		edit.Hash = hash
+	}
+
+	return edit, nil
+}
+
+func (e FileEdit) apply(tx *transaction) error {
+	mode := os.FileMode(0644)
+	info, err := os.Stat(e.Path)
+	switch {
+	case err == nil:
+		hash, err := HashFile(e.Path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to hash %s", e.Path)
+		}
+		if hash != e.Hash {
+			return errors.New("file changed since the plan was made: " + e.Path)
+		}
+		mode = info.Mode().Perm()
+	case os.IsNotExist(err):
+		if e.Hash != "" {
+			return errors.New("file removed since the plan was made: " + e.Path)
+		}
+	default:
+		return errors.Wrapf(err, "failed to stat %s", e.Path)
+	}
+
+	if err := tx.backup(e.Path, false); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(e.Path), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %s", e.Path)
+	}
+	if err := os.WriteFile(e.Path, e.Content, mode); err != nil {
+		return errors.Wrapf(err, "failed to write %s", e.Path)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package install
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrap(err, "failed to hash file")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isTextFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	sniff := make([]byte, 8000)
+	n, err := io.ReadFull(f, sniff)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	return isText(sniff[:n]), nil
+}
+
+func copyFile(src string, dst string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to stat source file")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return errors.Wrap(err, "failed to create destination directory")
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to open source file")
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, srcInfo.Mode().Perm())
+	if err != nil {
+		return errors.Wrap(err, "failed to create destination file")
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return errors.Wrap(err, "failed to copy file")
+	}
+
+	return errors.Wrap(out.Close(), "failed to close destination file")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// isInside returns whether p is in dir.
+func isInside(dir string, p string) bool {
+	relPath, err := filepath.Rel(dir, p)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)) && !filepath.IsAbs(relPath)
+}
+
+// movePath moves a file, symlink or directory. The install state is kept in the cache directory,
+// which can be on another file system than the plugins directory, so it falls back to copying.
+func movePath(src string, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return errors.Wrap(err, "failed to create destination directory")
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	} else if _, statErr := os.Lstat(src); statErr != nil {
+		return err
+	}
+
+	// Copy next to the destination first, so that an interruption never leaves an incomplete destination
+	partial := dst + partialSuffix
+	if err := os.RemoveAll(partial); err != nil {
+		return errors.Wrapf(err, "failed to clean %s", partial)
+	}
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(partial, relPath)
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.IsDir():
+			return os.MkdirAll(target, 0755)
+		default:
+			return copyFile(p, target)
+		}
+	})
+	if err != nil {
+		os.RemoveAll(partial)
+		return errors.Wrapf(err, "failed to move %s", src)
+	}
+	if err := os.Rename(partial, dst); err != nil {
+		os.RemoveAll(partial)
+		return errors.Wrapf(err, "failed to move %s", src)
+	}
+	return errors.Wrapf(os.RemoveAll(src), "failed to remove %s", src)
+}
@@ -0,0 +1,77 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// LinkMode is how the files of an installation are put in place. Linked files reference the source files,
+// which must then be left unchanged, like the files of the download cache.
+type LinkMode string
+
+const (
+	LinkCopy     LinkMode = "copy"
+	LinkSymlink  LinkMode = "symlink"
+	LinkHardlink LinkMode = "hardlink"
+	// LinkReflink makes copy-on-write clones, on filesystems that support them.
+	LinkReflink LinkMode = "reflink"
+)
+
+func ParseLinkMode(mode string) (LinkMode, error) {
+	switch LinkMode(mode) {
+	case "", LinkCopy:
+		return LinkCopy, nil
+	case LinkSymlink, LinkHardlink, LinkReflink:
+		return LinkMode(mode), nil
+	default:
+		return "", errors.Errorf("unknown link mode %s, expected copy, symlink, hardlink or reflink", mode)
+	}
+}
+
+// linkFile puts src at dst with the link mode, and returns the mode that was used.
+// Files that cannot be linked, such as across filesystems, are copied instead.
+func linkFile(src string, dst string, mode LinkMode) (LinkMode, error) {
+	if mode != "" && mode != LinkCopy {
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", errors.Wrap(err, "failed to create destination directory")
+		}
+
+		var err error
+		switch mode {
+		case LinkSymlink:
+			var absSrc string
+			absSrc, err = filepath.Abs(src)
+			if err == nil {
+				err = os.Symlink(absSrc, dst)
+			}
+		case LinkHardlink:
+			err = os.Link(src, dst)
+		case LinkReflink:
+			err = reflinkFile(src, dst)
+		}
+		if err == nil {
+			return mode, nil
+		}
+		_ = os.Remove(dst)
+	}
+
+	if err := copyFile(src, dst); err != nil {
+		return "", err
+	}
+	return LinkCopy, nil
+}
+
+// isLinkedTo returns whether the file at p is a symlink or hardlink to src.
+func isLinkedTo(p string, src string) bool {
+	info, err := os.Stat(p)
+	if err != nil {
+		return false
+	}
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(info, srcInfo)
+}
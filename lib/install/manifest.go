@@ -0,0 +1,128 @@
+package install
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// StateDir is the directory, inside the plugins directory the integration was installed to,
+// where wwise-cli keeps the install manifest.
+const StateDir = ".wwise-cli"
+
+// DataDir is where wwise-cli keeps the state of the installation to pluginsDir that only it needs, like the pristine
+// copies used as merge bases: in the cache directory, so that it ends up neither in builds nor in version control.
+func DataDir(pluginsDir string) string {
+	absPluginsDir, err := filepath.Abs(pluginsDir)
+	if err != nil {
+		absPluginsDir = pluginsDir
+	}
+	if runtime.GOOS == "windows" {
+		absPluginsDir = strings.ToLower(absPluginsDir)
+	}
+	sum := sha256.Sum256([]byte(absPluginsDir))
+	return filepath.Join(viper.GetString("cache-dir"), "installs", hex.EncodeToString(sum[:8]))
+}
+
+type Manifest struct {
+	IntegrationVersion string   `json:"integrationVersion"`
+	SdkVersion         string   `json:"sdkVersion"`
+	Platforms          []string `json:"platforms,omitempty"`
+	Configurations     []string `json:"configurations,omitempty"`
+	Plugins            []string `json:"plugins,omitempty"`
+	// Link is how the files that are not mergeable were installed. Empty means they were copied.
+	Link LinkMode `json:"link,omitempty"`
+	// Files maps the slash separated paths of the installed files, relative to the plugins directory, to the hash
+	// of the content they were left with.
+	Files map[string]string `json:"files,omitempty"`
+	// Upstream maps the files left with another content than the installation's, because local modifications were
+	// merged into them or kept, to the hash of the installation's content. Local modifications are detected against it.
+	Upstream map[string]string `json:"upstream,omitempty"`
+	// Edits are the changes the installations made to files outside them, oldest first. Uninstalling reverts only these.
+	Edits []EditRecord `json:"edits,omitempty"`
+}
+
+func (m *Manifest) Describe() string {
+	if m.SdkVersion == "" {
+		return m.IntegrationVersion
+	}
+	return fmt.Sprintf("%s (SDK %s)", m.IntegrationVersion, m.SdkVersion)
+}
+
+// upstreamHash returns the hash of the content the installation shipped for a file, and whether the file is part of it.
+func (m *Manifest) upstreamHash(file string) (string, bool) {
+	if hash, ok := m.Upstream[file]; ok {
+		return hash, true
+	}
+	hash, ok := m.Files[file]
+	return hash, ok
+}
+
+// linked returns whether the installation references its source files, rather than having its own copy.
+func (m *Manifest) linked() bool {
+	return m.Link == LinkSymlink || m.Link == LinkHardlink
+}
+
+// Roots returns the top level directories of the installed files, that is the installed plugins.
+func (m *Manifest) Roots() []string {
+	var roots []string
+	seen := make(map[string]bool)
+	for file := range m.Files {
+		root := strings.SplitN(file, "/", 2)[0]
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+func ManifestPath(pluginsDir string) string {
+	return filepath.Join(pluginsDir, StateDir, "install.json")
+}
+
+// ReadManifest returns the install manifest recorded in pluginsDir, or nil if there is none.
+func ReadManifest(pluginsDir string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(pluginsDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read install manifest")
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal install manifest")
+	}
+
+	return &m, nil
+}
+
+func (m *Manifest) Save(pluginsDir string) error {
+	file := ManifestPath(pluginsDir)
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return errors.Wrap(err, "failed to create install state directory")
+	}
+
+	manifestJson, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal install manifest")
+	}
+
+	if err := os.WriteFile(file, manifestJson, 0644); err != nil {
+		return errors.Wrap(err, "failed to write install manifest")
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package install
+
+import (
+	"bytes"
+	"strings"
+)
+
+// isText reports whether the content looks like a text file that can be merged line by line.
+func isText(content []byte) bool {
+	sniff := content
+	if len(sniff) > 8000 {
+		sniff = sniff[:8000]
+	}
+	return bytes.IndexByte(sniff, 0) == -1
+}
+
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines returns the pairs of indices of the lines of a and b that are part of their longest common subsequence,
+// using Myers' diff algorithm.
+func matchLines(a []string, b []string) [][2]int {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= n+m && !found; d++ {
+		trace = append(trace, append([]int{}, v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	var matches [][2]int
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			matches = append(matches, [2]int{x, y})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		matches = append(matches, [2]int{x, y})
+	}
+
+	for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+		matches[i], matches[j] = matches[j], matches[i]
+	}
+	return matches
+}
+
+// hunk is a range of the base that was replaced by a range of one side.
+type hunk struct {
+	side      int
+	baseStart int
+	baseEnd   int
+	sideStart int
+	sideEnd   int
+}
+
+func diffHunks(side int, base []string, other []string) []hunk {
+	var hunks []hunk
+	baseIdx, otherIdx := 0, 0
+	for _, match := range append(matchLines(base, other), [2]int{len(base), len(other)}) {
+		if match[0] > baseIdx || match[1] > otherIdx {
+			hunks = append(hunks, hunk{side: side, baseStart: baseIdx, baseEnd: match[0], sideStart: otherIdx, sideEnd: match[1]})
+		}
+		baseIdx, otherIdx = match[0]+1, match[1]+1
+	}
+	return hunks
+}
+
+func sameLines(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge3 merges the changes from base to local and from base to incoming.
+// Regions changed differently on both sides are written with diff3 style conflict markers, using the labels
+// of local, base and incoming. It returns the merged content and the number of conflicts.
+func Merge3(base []byte, local []byte, incoming []byte, labels [3]string) ([]byte, int) {
+	baseLines := splitLines(base)
+	sides := [2][]string{splitLines(local), splitLines(incoming)}
+
+	hunks := append(diffHunks(0, baseLines, sides[0]), diffHunks(1, baseLines, sides[1])...)
+	for i := 1; i < len(hunks); i++ {
+		for j := i; j > 0 && hunks[j].baseStart < hunks[j-1].baseStart; j-- {
+			hunks[j], hunks[j-1] = hunks[j-1], hunks[j]
+		}
+	}
+
+	eol := "\n"
+	if bytes.Contains(local, []byte("\r\n")) {
+		eol = "\r\n"
+	}
+
+	var out strings.Builder
+	// Lines inside conflict markers always need a line ending, the last line of the file might not have one
+	writeLines := func(lines []string, terminate bool) {
+		for _, line := range lines {
+			out.WriteString(line)
+		}
+		if terminate && len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+			out.WriteString(eol)
+		}
+	}
+
+	conflicts := 0
+	baseIdx := 0
+	for i := 0; i < len(hunks); {
+		regionStart, regionEnd := hunks[i].baseStart, hunks[i].baseEnd
+		group := []hunk{hunks[i]}
+		for i++; i < len(hunks) && hunks[i].baseStart <= regionEnd; i++ {
+			if hunks[i].baseEnd > regionEnd {
+				regionEnd = hunks[i].baseEnd
+			}
+			group = append(group, hunks[i])
+		}
+
+		for _, line := range baseLines[baseIdx:regionStart] {
+			out.WriteString(line)
+		}
+		baseIdx = regionEnd
+
+		// The range of each side that replaces the region, extended by the unchanged lines the region covers
+		var sideRanges [2][2]int
+		var changed [2]bool
+		for side := range sides {
+			first, last := -1, -1
+			for j, h := range group {
+				if h.side != side {
+					continue
+				}
+				if first == -1 {
+					first = j
+				}
+				last = j
+			}
+			if first == -1 {
+				continue
+			}
+			changed[side] = true
+			sideRanges[side] = [2]int{
+				group[first].sideStart - (group[first].baseStart - regionStart),
+				group[last].sideEnd + (regionEnd - group[last].baseEnd),
+			}
+		}
+
+		if !changed[1] {
+			writeLines(sides[0][sideRanges[0][0]:sideRanges[0][1]], false)
+			continue
+		}
+		if !changed[0] {
+			writeLines(sides[1][sideRanges[1][0]:sideRanges[1][1]], false)
+			continue
+		}
+
+		localLines := sides[0][sideRanges[0][0]:sideRanges[0][1]]
+		incomingLines := sides[1][sideRanges[1][0]:sideRanges[1][1]]
+		if sameLines(localLines, incomingLines) {
+			writeLines(localLines, false)
+			continue
+		}
+
+		conflicts++
+		out.WriteString("<<<<<<< " + labels[0] + eol)
+		writeLines(localLines, true)
+		out.WriteString("||||||| " + labels[1] + eol)
+		writeLines(baseLines[regionStart:regionEnd], true)
+		out.WriteString("=======" + eol)
+		writeLines(incomingLines, true)
+		out.WriteString(">>>>>>> " + labels[2] + eol)
+	}
+
+	for _, line := range baseLines[baseIdx:] {
+		out.WriteString(line)
+	}
+
+	return []byte(out.String()), conflicts
+}
@@ -0,0 +1,180 @@
+package install
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	labels := [3]string{"local", "base", "incoming"}
+	tests := []struct {
+		name      string
+		base      string
+		local     string
+		incoming  string
+		want      string
+		conflicts int
+	}{
+		{
+			name:     "unchanged",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\n",
+			incoming: "a\nb\nc\n",
+			want:     "a\nb\nc\n",
+		},
+		{
+			name:     "only local changes",
+			base:     "a\nb\nc\n",
+			local:    "a\nB\nc\n",
+			incoming: "a\nb\nc\n",
+			want:     "a\nB\nc\n",
+		},
+		{
+			name:     "only incoming changes",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\n",
+			incoming: "a\nb\nC\n",
+			want:     "a\nb\nC\n",
+		},
+		{
+			name:     "changes to different lines",
+			base:     "a\nb\nc\nd\ne\n",
+			local:    "A\nb\nc\nd\ne\n",
+			incoming: "a\nb\nc\nd\nE\n",
+			want:     "A\nb\nc\nd\nE\n",
+		},
+		{
+			name:     "same change on both sides",
+			base:     "a\nb\nc\n",
+			local:    "a\nB\nc\n",
+			incoming: "a\nB\nc\n",
+			want:     "a\nB\nc\n",
+		},
+		{
+			name:     "local insertion at the start, incoming change at the end",
+			base:     "a\nb\nc\n",
+			local:    "#include \"Patch.h\"\na\nb\nc\n",
+			incoming: "a\nb\nC\n",
+			want:     "#include \"Patch.h\"\na\nb\nC\n",
+		},
+		{
+			name:     "local insertion at the end, incoming change at the start",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\n// patched\n",
+			incoming: "A\nb\nc\n",
+			want:     "A\nb\nc\n// patched\n",
+		},
+		{
+			name:      "insertions at the start on both sides",
+			base:      "a\nb\n",
+			local:     "l\na\nb\n",
+			incoming:  "i\na\nb\n",
+			want:      "<<<<<<< local\nl\n||||||| base\n=======\ni\n>>>>>>> incoming\na\nb\n",
+			conflicts: 1,
+		},
+		{
+			name:     "incoming insertion at the end of a file without a final line ending",
+			base:     "a\nb\nc",
+			local:    "A\nb\nc",
+			incoming: "a\nb\nc\nd",
+			want:     "A\nb\nc\nd",
+		},
+		{
+			// Like diff3, changes to adjacent lines are not told apart
+			name:      "changes to adjacent lines",
+			base:      "a\nb\n",
+			local:     "A\nb\n",
+			incoming:  "a\nB\n",
+			want:      "<<<<<<< local\nA\nb\n||||||| base\na\nb\n=======\na\nB\n>>>>>>> incoming\n",
+			conflicts: 1,
+		},
+		{
+			name:     "deletion and unrelated change",
+			base:     "a\nb\nc\nd\n",
+			local:    "a\nc\nd\n",
+			incoming: "a\nb\nc\nD\n",
+			want:     "a\nc\nD\n",
+		},
+		{
+			name:      "conflicting changes",
+			base:      "a\nb\nc\n",
+			local:     "a\nlocal\nc\n",
+			incoming:  "a\nincoming\nc\n",
+			want:      "a\n<<<<<<< local\nlocal\n||||||| base\nb\n=======\nincoming\n>>>>>>> incoming\nc\n",
+			conflicts: 1,
+		},
+		{
+			name:      "conflict on the last line without a line ending",
+			base:      "a\nb",
+			local:     "a\nlocal",
+			incoming:  "a\nincoming",
+			want:      "a\n<<<<<<< local\nlocal\n||||||| base\nb\n=======\nincoming\n>>>>>>> incoming\n",
+			conflicts: 1,
+		},
+		{
+			name:      "two conflicts",
+			base:      "a\nb\nc\nd\ne\n",
+			local:     "A1\nb\nc\nd\nE1\n",
+			incoming:  "A2\nb\nc\nd\nE2\n",
+			want:      "<<<<<<< local\nA1\n||||||| base\na\n=======\nA2\n>>>>>>> incoming\nb\nc\nd\n<<<<<<< local\nE1\n||||||| base\ne\n=======\nE2\n>>>>>>> incoming\n",
+			conflicts: 2,
+		},
+		{
+			name:     "CRLF changes to different lines",
+			base:     "a\r\nb\r\nc\r\n",
+			local:    "A\r\nb\r\nc\r\n",
+			incoming: "a\r\nb\r\nC\r\n",
+			want:     "A\r\nb\r\nC\r\n",
+		},
+		{
+			name:      "CRLF conflict markers",
+			base:      "a\r\nb\r\n",
+			local:     "a\r\nlocal\r\n",
+			incoming:  "a\r\nincoming\r\n",
+			want:      "a\r\n<<<<<<< local\r\nlocal\r\n||||||| base\r\nb\r\n=======\r\nincoming\r\n>>>>>>> incoming\r\n",
+			conflicts: 1,
+		},
+		{
+			name:      "no base",
+			base:      "",
+			local:     "a\nlocal\n",
+			incoming:  "a\nincoming\n",
+			want:      "<<<<<<< local\na\nlocal\n||||||| base\n=======\na\nincoming\n>>>>>>> incoming\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, conflicts := Merge3([]byte(test.base), []byte(test.local), []byte(test.incoming), labels)
+			if string(merged) != test.want {
+				t.Errorf("merged:\n%s\nwant:\n%s", strings.ReplaceAll(string(merged), "\r", `\r`), strings.ReplaceAll(test.want, "\r", `\r`))
+			}
+			if conflicts != test.conflicts {
+				t.Errorf("%d conflicts, want %d", conflicts, test.conflicts)
+			}
+		})
+	}
+}
+
+func TestMatchLines(t *testing.T) {
+	tests := []struct {
+		a    []string
+		b    []string
+		want [][2]int
+	}{
+		{nil, nil, nil},
+		{[]string{"a"}, nil, nil},
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}, [][2]int{{0, 0}, {1, 1}, {2, 2}}},
+		{[]string{"a", "b", "c"}, []string{"x", "a", "b", "c"}, [][2]int{{0, 1}, {1, 2}, {2, 3}}},
+		{[]string{"a", "b", "c"}, []string{"a", "c"}, [][2]int{{0, 0}, {2, 1}}},
+		{[]string{"a", "b", "c", "a", "b", "b", "a"}, []string{"c", "b", "a", "b", "a", "c"}, [][2]int{{2, 0}, {3, 2}, {4, 3}, {6, 4}}},
+	}
+	for _, test := range tests {
+		got := matchLines(test.a, test.b)
+		if len(got) != len(test.want) || (len(got) > 0 && !reflect.DeepEqual(got, test.want)) {
+			t.Errorf("matchLines(%q, %q) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
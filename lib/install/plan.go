@@ -0,0 +1,302 @@
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionOverwrite Action = "overwrite"
+	ActionUnchanged Action = "unchanged"
+	// ActionKeep leaves a locally modified file as is, because the new installation did not change it.
+	ActionKeep Action = "keep"
+	// ActionMerge merges the changes the new installation made to a file with its local modifications.
+	ActionMerge Action = "merge"
+	// ActionConflict keeps a locally modified file that cannot be merged, and writes the new version next to it, as .new.
+	ActionConflict Action = "conflict"
+	// ActionDelete removes files installed by the previous installation that are not part of the new one.
+	ActionDelete Action = "delete"
+	// ActionExtra marks files that exist in the installation's directories, but are not part of the new installation.
+	// They are reported, but left untouched.
+	ActionExtra Action = "extra"
+)
+
+// File is a file of an installation: the absolute path of its source, and its slash separated destination
+// relative to the plugins directory. Local modifications of mergeable text files are preserved across upgrades.
+type File struct {
+	Path      string
+	Source    string
+	Mergeable bool
+}
+
+type FileOperation struct {
+	Action    Action `json:"action"`
+	Path      string `json:"path"`
+	Source    string `json:"source,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Mergeable bool   `json:"mergeable,omitempty"`
+}
+
+type Plan struct {
+	PluginsDir string          `json:"pluginsDir"`
+	Manifest   Manifest        `json:"manifest"`
+	Previous   *Manifest       `json:"previous,omitempty"`
+	Operations []FileOperation `json:"operations"`
+	Edits      []FileEdit      `json:"edits,omitempty"`
+}
+
+// NewPlan compares the files of an installation against what is currently in pluginsDir.
+// The manifest is the install manifest that is recorded once the plan is applied.
+// Files of the previous installation that are not part of the new one are deleted, unless they were modified.
+func NewPlan(pluginsDir string, files []File, manifest Manifest, previous *Manifest) (*Plan, error) {
+	plan := &Plan{
+		PluginsDir: pluginsDir,
+		Manifest:   manifest,
+		Previous:   previous,
+		Operations: []FileOperation{},
+	}
+
+	// Unchanged files are only relinked if the link mode changed, as files that could not be linked are copied
+	relink := previous != nil && previous.linked() != manifest.linked()
+
+	// A file installed more than once is taken from its last source
+	planned := make(map[string]bool)
+	roots := make(map[string]bool)
+	lastFiles := make(map[string]File)
+	for _, file := range files {
+		lastFiles[file.Path] = file
+	}
+	for _, file := range files {
+		if planned[file.Path] {
+			continue
+		}
+		planned[file.Path] = true
+		roots[strings.SplitN(file.Path, "/", 2)[0]] = true
+		file = lastFiles[file.Path]
+
+		sourceHash, err := HashFile(file.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to hash %s", file.Source)
+		}
+
+		mergeable := false
+		if file.Mergeable {
+			mergeable, err = isTextFile(file.Source)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read %s", file.Source)
+			}
+		}
+
+		action := ActionCreate
+		dest := filepath.Join(pluginsDir, filepath.FromSlash(file.Path))
+		if _, err := os.Stat(dest); err == nil {
+			destHash, err := HashFile(dest)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to hash %s", dest)
+			}
+			action = ActionOverwrite
+			if destHash == sourceHash {
+				action = ActionUnchanged
+				if relink && !mergeable && isLinkedTo(dest, file.Source) != manifest.linked() {
+					action = ActionOverwrite
+				}
+			} else if previous != nil {
+				if previousHash, ok := previous.upstreamHash(file.Path); ok && destHash != previousHash {
+					// Modified since the previous installation
+					switch {
+					case sourceHash == previousHash:
+						action = ActionKeep
+					case mergeable:
+						action = ActionMerge
+					default:
+						action = ActionConflict
+					}
+				}
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to stat %s", dest)
+		}
+
+		plan.Operations = append(plan.Operations, FileOperation{
+			Action:    action,
+			Path:      file.Path,
+			Source:    file.Source,
+			Hash:      sourceHash,
+			Mergeable: mergeable,
+		})
+	}
+
+	if previous != nil {
+		for previousFile := range previous.Files {
+			if planned[previousFile] {
+				continue
+			}
+			previousHash, _ := previous.upstreamHash(previousFile)
+			roots[strings.SplitN(previousFile, "/", 2)[0]] = true
+
+			dest := filepath.Join(pluginsDir, filepath.FromSlash(previousFile))
+			destHash, err := HashFile(dest)
+			if err != nil {
+				if os.IsNotExist(errors.Cause(err)) {
+					continue
+				}
+				return nil, errors.Wrapf(err, "failed to hash %s", dest)
+			}
+
+			// Modified files are reported as extra files below
+			if destHash == previousHash {
+				planned[previousFile] = true
+				plan.Operations = append(plan.Operations, FileOperation{
+					Action: ActionDelete,
+					Path:   previousFile,
+					Hash:   previousHash,
+				})
+			}
+		}
+	}
+
+	for root := range roots {
+		err := filepath.WalkDir(filepath.Join(pluginsDir, root), func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			relPath, err := filepath.Rel(pluginsDir, p)
+			if err != nil {
+				return err
+			}
+			relPath = filepath.ToSlash(relPath)
+			if !planned[relPath] {
+				plan.Operations = append(plan.Operations, FileOperation{
+					Action: ActionExtra,
+					Path:   relPath,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list existing files of %s", root)
+		}
+	}
+
+	sort.SliceStable(plan.Operations, func(i, j int) bool {
+		return plan.Operations[i].Path < plan.Operations[j].Path
+	})
+
+	return plan, nil
+}
+
+func ReadPlan(file string) (*Plan, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read plan")
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal plan")
+	}
+	return &plan, nil
+}
+
+func (p *Plan) Save(file string) error {
+	planJson, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal plan")
+	}
+
+	if err := os.WriteFile(file, planJson, 0644); err != nil {
+		return errors.Wrap(err, "failed to write plan")
+	}
+	return nil
+}
+
+func (p *Plan) Count(action Action) int {
+	count := 0
+	for _, op := range p.Operations {
+		if op.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+var summarySections = []struct {
+	action Action
+	title  string
+	symbol string
+}{
+	{ActionCreate, "New", "+"},
+	{ActionOverwrite, "Overwritten", "~"},
+	{ActionMerge, "Merged with local modifications", "M"},
+	{ActionConflict, "Local modifications kept, new version written next to them as .new", "C"},
+	{ActionKeep, "Local modifications kept", "="},
+	{ActionDelete, "Removed", "-"},
+	{ActionExtra, "Not part of the integration (left untouched)", "?"},
+}
+
+// PrintSummary writes a human-readable summary of the plan: the count of each action, then the files of each action.
+// Files directly in the same directory are printed as a single line with their count, subdirectories get their own lines.
+func (p *Plan) PrintSummary(w io.Writer) {
+	fmt.Fprintf(w, "Plan for %s\n", p.PluginsDir)
+	if p.Previous == nil {
+		fmt.Fprintf(w, "  Installing %s\n", p.Manifest.Describe())
+	} else if p.Previous.Describe() != p.Manifest.Describe() {
+		fmt.Fprintf(w, "  Upgrading %s to %s\n", p.Previous.Describe(), p.Manifest.Describe())
+	} else {
+		fmt.Fprintf(w, "  Reinstalling %s\n", p.Manifest.Describe())
+	}
+	fmt.Fprintf(w, "  %d new, %d overwritten, %d merged, %d conflicting, %d kept, %d removed, %d unchanged, %d not part of the integration\n",
+		p.Count(ActionCreate), p.Count(ActionOverwrite), p.Count(ActionMerge), p.Count(ActionConflict), p.Count(ActionKeep), p.Count(ActionDelete), p.Count(ActionUnchanged), p.Count(ActionExtra))
+
+	for _, section := range summarySections {
+		if p.Count(section.action) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s:\n", section.title)
+		var dirs []string
+		dirFiles := make(map[string][]string)
+		for _, op := range p.Operations {
+			if op.Action != section.action {
+				continue
+			}
+			dir := path.Dir(op.Path)
+			if _, ok := dirFiles[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+			dirFiles[dir] = append(dirFiles[dir], op.Path)
+		}
+		for _, dir := range dirs {
+			if len(dirFiles[dir]) == 1 {
+				fmt.Fprintf(w, "  %s %s\n", section.symbol, dirFiles[dir][0])
+			} else {
+				fmt.Fprintf(w, "  %s %s/ (%d files)\n", section.symbol, dir, len(dirFiles[dir]))
+			}
+		}
+	}
+
+	if len(p.Edits) > 0 {
+		fmt.Fprintf(w, "\nEdited:\n")
+		for _, edit := range p.Edits {
+			fmt.Fprintf(w, "  ~ %s (%s)\n", edit.Path, edit.Description)
+		}
+	}
+}
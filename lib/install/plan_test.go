@@ -0,0 +1,140 @@
+package install
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewPlanActions(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{
+		"Wwise/same.txt":             "same",
+		"Wwise/changed.txt":          "changed 1",
+		"Wwise/kept.txt":             "kept",
+		"Wwise/merged.txt":           "merged 1\n\nkeep\n",
+		"Wwise/removed.txt":          "removed",
+		"Wwise/removedModified.txt":  "removed",
+		"Wwise/Source/unchanged.cpp": "unchanged",
+	}, true)
+	writeFiles(t, pluginsDir, map[string]string{
+		"Wwise/kept.txt":            "kept, patched",
+		"Wwise/merged.txt":          "merged 1\n\nkeep, patched\n",
+		"Wwise/removedModified.txt": "removed, patched",
+		"Wwise/extra.txt":           "added by the project",
+		"Wwise/unmanaged.txt":       "not installed by wwise-cli",
+		"Other/untouched.txt":       "other plugin",
+	})
+
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, map[string]string{
+		"Wwise/same.txt":             "same",
+		"Wwise/changed.txt":          "changed 2",
+		"Wwise/kept.txt":             "kept",
+		"Wwise/merged.txt":           "merged 2\n\nkeep\n",
+		"Wwise/new.txt":              "new",
+		"Wwise/unmanaged.txt":        "installed now",
+		"Wwise/Source/unchanged.cpp": "unchanged",
+	}, true), Manifest{IntegrationVersion: "2"}, mustReadManifest(t, pluginsDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Action{
+		"Wwise/same.txt":             ActionUnchanged,
+		"Wwise/changed.txt":          ActionOverwrite,
+		"Wwise/kept.txt":             ActionKeep,
+		"Wwise/merged.txt":           ActionMerge,
+		"Wwise/new.txt":              ActionCreate,
+		"Wwise/unmanaged.txt":        ActionOverwrite,
+		"Wwise/removed.txt":          ActionDelete,
+		"Wwise/removedModified.txt":  ActionExtra,
+		"Wwise/extra.txt":            ActionExtra,
+		"Wwise/Source/unchanged.cpp": ActionUnchanged,
+	}
+	if got := actions(plan); !reflect.DeepEqual(got, want) {
+		t.Errorf("actions:\n%v\nwant:\n%v", got, want)
+	}
+
+	// The plan is applied as it was planned
+	if _, err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	assertFiles(t, pluginsDir, map[string]string{
+		"Wwise/changed.txt":         "changed 2",
+		"Wwise/kept.txt":            "kept, patched",
+		"Wwise/merged.txt":          "merged 2\n\nkeep, patched\n",
+		"Wwise/new.txt":             "new",
+		"Wwise/unmanaged.txt":       "installed now",
+		"Wwise/removed.txt":         "",
+		"Wwise/removedModified.txt": "removed, patched",
+		"Wwise/extra.txt":           "added by the project",
+		"Other/untouched.txt":       "other plugin",
+	})
+}
+
+func TestNewPlanWithoutPreviousInstallation(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "local", "Wwise/b.txt": "b"})
+
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, map[string]string{"Wwise/a.txt": "a", "Wwise/b.txt": "b"}, true), Manifest{IntegrationVersion: "1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without a manifest there is no telling local modifications apart
+	want := map[string]Action{"Wwise/a.txt": ActionOverwrite, "Wwise/b.txt": ActionUnchanged}
+	if got := actions(plan); !reflect.DeepEqual(got, want) {
+		t.Errorf("actions %v, want %v", got, want)
+	}
+}
+
+func TestApplyPristineCopies(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	files := append(sourceFiles(t, map[string]string{"Wwise/Source/a.cpp": "a"}, true), sourceFiles(t, map[string]string{"Wwise/ThirdParty/lib.a": "lib"}, false)...)
+	plan, err := NewPlan(pluginsDir, files, Manifest{IntegrationVersion: "1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only mergeable files are kept as merge bases, out of the plugins directory
+	assertFiles(t, pristineDir(pluginsDir), map[string]string{"Wwise/Source/a.cpp": "a", "Wwise/ThirdParty/lib.a": ""})
+	if _, err := os.Stat(filepath.Join(pluginsDir, "Wwise", "Source", "a.cpp")); err != nil {
+		t.Error(err)
+	}
+
+	// The pristine copies are the ones of the last installation
+	install(t, pluginsDir, "2", map[string]string{"Wwise/Source/b.cpp": "b"}, true)
+	assertFiles(t, pristineDir(pluginsDir), map[string]string{"Wwise/Source/a.cpp": "", "Wwise/Source/b.cpp": "b"})
+}
+
+func TestPlanPrintSummary(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, map[string]string{
+		"Wwise/Wwise.uplugin":                  "{}",
+		"Wwise/ThirdParty/Win64/Release/a.lib": "a",
+		"Wwise/ThirdParty/Win64/Release/b.lib": "b",
+		"Wwise/ThirdParty/Win64/Debug/a.lib":   "a",
+	}, false), Manifest{IntegrationVersion: "1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	plan.PrintSummary(&out)
+	for _, line := range []string{
+		"  Installing 1\n",
+		"  4 new, 0 overwritten",
+		"  + Wwise/ThirdParty/Win64/Debug/a.lib\n",
+		"  + Wwise/ThirdParty/Win64/Release/ (2 files)\n",
+		"  + Wwise/Wwise.uplugin\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("summary misses %q:\n%s", line, out.String())
+		}
+	}
+}
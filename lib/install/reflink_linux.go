@@ -0,0 +1,33 @@
+package install
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+func reflinkFile(src string, dst string) error {
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to stat source file")
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to open source file")
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, srcInfo.Mode().Perm())
+	if err != nil {
+		return errors.Wrap(err, "failed to create destination file")
+	}
+
+	if err := unix.IoctlFileClone(int(out.Fd()), int(in.Fd())); err != nil {
+		out.Close()
+		return errors.Wrap(err, "failed to clone file")
+	}
+
+	return errors.Wrap(out.Close(), "failed to close destination file")
+}
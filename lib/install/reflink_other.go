@@ -0,0 +1,11 @@
+//go:build !linux
+
+package install
+
+import (
+	"github.com/pkg/errors"
+)
+
+func reflinkFile(src string, dst string) error {
+	return errors.New("reflinks are not supported on this platform")
+}
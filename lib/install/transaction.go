@@ -0,0 +1,242 @@
+package install
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const journalFile = "journal.jsonl"
+
+// partialSuffix marks files that are still being written, which are only renamed to their name once complete.
+const partialSuffix = ".partial"
+
+// journalEntry records the state of a path before a transaction changed it.
+type journalEntry struct {
+	// Path is slash separated, relative to the plugins directory, or absolute for paths outside it,
+	// like the project file or the install state in the cache directory.
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+	// Backup is the slash separated path of the original, relative to the transaction directory.
+	Backup string `json:"backup,omitempty"`
+}
+
+// transaction backs up everything an installation changes, so that a failed installation can be undone,
+// and the last successful one can be rolled back. The journal is written as the installation progresses,
+// so that even an interrupted installation can be undone. Only the backup of the last installation is kept,
+// in the backup directory of the data directory.
+type transaction struct {
+	pluginsDir string
+	dir        string
+	journal    *os.File
+	backedUp   map[string]bool
+}
+
+// BackupDir is where the backup of the last installation to pluginsDir is kept, until the next one replaces it.
+func BackupDir(pluginsDir string) string {
+	return filepath.Join(DataDir(pluginsDir), "backup")
+}
+
+func beginTransaction(pluginsDir string) (*transaction, error) {
+	dir := BackupDir(pluginsDir) + ".new"
+
+	if fileExists(filepath.Join(dir, journalFile)) {
+		// An interrupted installation left its journal behind, undo it before starting over
+		if err := rollbackDir(pluginsDir, dir); err != nil {
+			return nil, errors.Wrap(err, "failed to undo interrupted installation")
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, errors.Wrap(err, "failed to clean backup directory")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "files"), 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create backup directory")
+	}
+
+	journal, err := os.Create(filepath.Join(dir, journalFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create journal")
+	}
+
+	return &transaction{
+		pluginsDir: pluginsDir,
+		dir:        dir,
+		journal:    journal,
+		backedUp:   make(map[string]bool),
+	}, nil
+}
+
+// backup records the current state of a file or directory before it gets changed.
+// If move is set, the original is moved into the backup instead of copied, as the caller is replacing or removing it anyway.
+func (t *transaction) backup(p string, move bool) error {
+	if t.backedUp[p] {
+		return nil
+	}
+
+	entry := journalEntry{}
+	if isInside(t.pluginsDir, p) {
+		relPath, err := filepath.Rel(t.pluginsDir, p)
+		if err != nil {
+			return errors.Wrap(err, "failed to get relative path")
+		}
+		entry.Path = filepath.ToSlash(relPath)
+	} else {
+		absPath, err := filepath.Abs(p)
+		if err != nil {
+			return errors.Wrap(err, "failed to get absolute path")
+		}
+		entry.Path = filepath.ToSlash(absPath)
+	}
+
+	info, err := os.Lstat(p)
+	if err == nil {
+		entry.Existed = true
+		entry.Backup = path.Join("files", strconv.Itoa(len(t.backedUp)))
+	} else if !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to stat %s", p)
+	}
+
+	// The entry is on disk before anything is changed, so that an interruption never leaves a change without its entry.
+	// Rolling back skips the entries whose backup was not made, as their path was not changed yet.
+	entryJson, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal entry")
+	}
+	if _, err := t.journal.Write(append(entryJson, '\n')); err != nil {
+		return errors.Wrap(err, "failed to write journal")
+	}
+	if err := t.journal.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync journal")
+	}
+
+	if entry.Existed {
+		backup := filepath.Join(t.dir, filepath.FromSlash(entry.Backup))
+		if move {
+			if err := movePath(p, backup); err != nil {
+				return errors.Wrapf(err, "failed to back up %s", p)
+			}
+		} else {
+			if info.IsDir() {
+				return errors.New("cannot copy directory to backup: " + p)
+			}
+			// The backup only shows up once it is complete
+			if err := copyFile(p, backup+partialSuffix); err != nil {
+				return errors.Wrapf(err, "failed to back up %s", p)
+			}
+			if err := os.Rename(backup+partialSuffix, backup); err != nil {
+				return errors.Wrapf(err, "failed to back up %s", p)
+			}
+		}
+	}
+
+	t.backedUp[p] = true
+	return nil
+}
+
+func (t *transaction) rollback() error {
+	t.journal.Close()
+	return rollbackDir(t.pluginsDir, t.dir)
+}
+
+// commit keeps the backup of the transaction as the backup of the last installation, replacing the previous one.
+func (t *transaction) commit() error {
+	if err := t.journal.Close(); err != nil {
+		return errors.Wrap(err, "failed to close journal")
+	}
+	if err := os.RemoveAll(BackupDir(t.pluginsDir)); err != nil {
+		return errors.Wrap(err, "failed to remove previous backup")
+	}
+	if err := movePath(t.dir, BackupDir(t.pluginsDir)); err != nil {
+		return errors.Wrap(err, "failed to save backup")
+	}
+	return nil
+}
+
+// rollbackDir restores everything recorded in the journal of a transaction directory, then removes it.
+func rollbackDir(pluginsDir string, dir string) error {
+	journal, err := os.Open(filepath.Join(dir, journalFile))
+	if err != nil {
+		return errors.Wrap(err, "failed to open journal")
+	}
+
+	var entries []journalEntry
+	scanner := bufio.NewScanner(journal)
+	for scanner.Scan() {
+		var entry journalEntry
+		// The last entry of an interrupted installation may be incomplete, and was not acted upon
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	journal.Close()
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "failed to read journal")
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		p := filepath.FromSlash(entry.Path)
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(pluginsDir, p)
+		}
+
+		backup := filepath.Join(dir, filepath.FromSlash(entry.Backup))
+		if entry.Existed {
+			if _, err := os.Lstat(backup); os.IsNotExist(err) {
+				// Interrupted before the path was backed up, or restored by an interrupted rollback: the original is in place
+				continue
+			}
+		}
+
+		if err := os.RemoveAll(p); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", entry.Path)
+		}
+
+		if !entry.Existed {
+			removeEmptyParents(pluginsDir, p)
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return errors.Wrapf(err, "failed to create directory of %s", entry.Path)
+		}
+		if err := movePath(backup, p); err != nil {
+			return errors.Wrapf(err, "failed to restore %s", entry.Path)
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Wrap(err, "failed to remove backup")
+	}
+	return nil
+}
+
+// HasBackup reports whether there is an installation to pluginsDir to roll back, interrupted or not.
+func HasBackup(pluginsDir string) bool {
+	return fileExists(filepath.Join(BackupDir(pluginsDir)+".new", journalFile)) || fileExists(filepath.Join(BackupDir(pluginsDir), journalFile))
+}
+
+// Rollback undoes the last installation applied to pluginsDir, using its backup.
+// If an installation was interrupted, it is the one that is undone.
+func Rollback(pluginsDir string) error {
+	if interrupted := BackupDir(pluginsDir) + ".new"; fileExists(filepath.Join(interrupted, journalFile)) {
+		return rollbackDir(pluginsDir, interrupted)
+	}
+
+	dir := BackupDir(pluginsDir)
+	if _, err := os.Stat(filepath.Join(dir, journalFile)); err != nil {
+		if os.IsNotExist(err) {
+			return errors.New("no installation to roll back")
+		}
+		return errors.Wrap(err, "failed to read backup")
+	}
+
+	return rollbackDir(pluginsDir, dir)
+}
@@ -0,0 +1,159 @@
+package install
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// writeFiles writes files, by slash separated path relative to dir, with their content.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for file, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// assertFiles checks the content of files, by slash separated path relative to dir. An empty content means the file must not exist.
+func assertFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for file, want := range files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if want == "" {
+			if !os.IsNotExist(err) {
+				t.Errorf("%s exists, want it removed", file)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+		} else if string(content) != want {
+			t.Errorf("%s = %q, want %q", file, content, want)
+		}
+	}
+}
+
+// newTestInstall sets up a cache directory and a plugins directory, and returns the plugins directory.
+func newTestInstall(t *testing.T) string {
+	t.Helper()
+	viper.Set("cache-dir", t.TempDir())
+	return filepath.Join(t.TempDir(), "Plugins")
+}
+
+// sourceFiles writes the files of an installation to a source directory, and returns them to be installed.
+func sourceFiles(t *testing.T, files map[string]string, mergeable bool) []File {
+	t.Helper()
+	sourceDir := t.TempDir()
+	writeFiles(t, sourceDir, files)
+	var installFiles []File
+	for file := range files {
+		installFiles = append(installFiles, File{Path: file, Source: filepath.Join(sourceDir, filepath.FromSlash(file)), Mergeable: mergeable})
+	}
+	return installFiles
+}
+
+// install plans and applies the installation of files, over what was installed before.
+func install(t *testing.T, pluginsDir string, version string, files map[string]string, mergeable bool) (*Plan, *Result) {
+	t.Helper()
+	previous, err := ReadManifest(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, files, mergeable), Manifest{IntegrationVersion: version}, previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := plan.Apply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plan, result
+}
+
+func TestRollbackInterruptedApply(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/a.txt": "a1", "Wwise/old.txt": "old"}, false)
+
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, map[string]string{"Wwise/a.txt": "a2", "Wwise/b.txt": "b2"}, false), Manifest{IntegrationVersion: "2"}, mustReadManifest(t, pluginsDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Apply without committing, like a process killed right before the end
+	tx, err := beginTransaction(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plan.apply(tx); err != nil {
+		t.Fatal(err)
+	}
+	tx.journal.Close()
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "a2", "Wwise/b.txt": "b2", "Wwise/old.txt": ""})
+
+	if err := Rollback(pluginsDir); err != nil {
+		t.Fatal(err)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "a1", "Wwise/b.txt": "", "Wwise/old.txt": "old"})
+	if manifest := mustReadManifest(t, pluginsDir); manifest.IntegrationVersion != "1" {
+		t.Errorf("manifest of version %s after rolling back, want 1", manifest.IntegrationVersion)
+	}
+
+	// The backup of the installation of version 1 is still there
+	if err := Rollback(pluginsDir); err != nil {
+		t.Fatal(err)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "", "Wwise/old.txt": ""})
+}
+
+func TestRollbackEntryWithoutBackup(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "a", "Wwise/b.txt": "b"})
+
+	// Interrupted right after journaling a.txt, and while copying b.txt to the backup
+	tx, err := beginTransaction(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, file := range []string{"Wwise/a.txt", "Wwise/b.txt"} {
+		entry, err := json.Marshal(journalEntry{Path: file, Existed: true, Backup: "files/" + string(rune('0'+i))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.journal.Write(append(entry, '\n')); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tx.journal.Close()
+	writeFiles(t, tx.dir, map[string]string{"files/1" + partialSuffix: "incomplete"})
+
+	// The next transaction undoes the interrupted one first
+	tx, err = beginTransaction(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.rollback(); err != nil {
+		t.Fatal(err)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "a", "Wwise/b.txt": "b"})
+}
+
+func mustReadManifest(t *testing.T, pluginsDir string) *Manifest {
+	t.Helper()
+	manifest, err := ReadManifest(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest == nil {
+		t.Fatal("no install manifest in " + pluginsDir)
+	}
+	return manifest
+}
@@ -0,0 +1,133 @@
+package install
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+type UninstallResult struct {
+	Removed int
+	// Kept are the modified or added files that were left in place.
+	Kept []string
+	// Reverted are the files outside the installation whose edits were reverted.
+	Reverted []string
+}
+
+func (r *UninstallResult) PrintSummary(w io.Writer) {
+	fmt.Fprintf(w, "Removed %d files\n", r.Removed)
+	for _, reverted := range r.Reverted {
+		fmt.Fprintf(w, "Reverted the changes to %s\n", reverted)
+	}
+	if len(r.Kept) > 0 {
+		fmt.Fprintf(w, "Kept %d modified or added files, use --force to remove them:\n", len(r.Kept))
+		for _, kept := range r.Kept {
+			fmt.Fprintf(w, "  %s\n", kept)
+		}
+	}
+}
+
+// Uninstall removes the files recorded in the install manifest from pluginsDir, along with the install state, and applies
+// the edits that revert the changes recorded in the manifest to files outside the installation.
+// Files modified since they were installed, and files added to the installation's directories, are kept unless force is set.
+// Like Apply, everything goes through a transaction, so that the uninstallation can be rolled back.
+func Uninstall(pluginsDir string, installed *Manifest, edits []FileEdit, force bool) (*UninstallResult, error) {
+	tx, err := beginTransaction(pluginsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+
+	result, err := uninstall(tx, pluginsDir, installed, edits, force)
+	if err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			return nil, errors.Wrapf(err, "failed to restore the original files (%s)", rollbackErr.Error())
+		}
+		return nil, errors.Wrap(err, "restored the original files")
+	}
+
+	if err := tx.commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return result, nil
+}
+
+func uninstall(tx *transaction, pluginsDir string, installed *Manifest, edits []FileEdit, force bool) (*UninstallResult, error) {
+	result := &UninstallResult{}
+
+	for file := range installed.Files {
+		hash, _ := installed.upstreamHash(file)
+		dest := filepath.Join(pluginsDir, filepath.FromSlash(file))
+		destHash, err := HashFile(dest)
+		if err != nil {
+			if !os.IsNotExist(errors.Cause(err)) {
+				return nil, errors.Wrapf(err, "failed to hash %s", dest)
+			}
+			// Symlinks to a download cache that was cleaned up are still part of the installation
+			if info, err := os.Lstat(dest); err != nil || info.Mode()&os.ModeSymlink == 0 {
+				continue
+			}
+		} else if destHash != hash && !force {
+			continue
+		}
+		// Moving the file to the backup removes it
+		if err := tx.backup(dest, true); err != nil {
+			return nil, err
+		}
+		removeEmptyParents(pluginsDir, dest)
+		result.Removed++
+	}
+
+	for _, root := range installed.Roots() {
+		rootDir := filepath.Join(pluginsDir, root)
+		if force {
+			if err := tx.backup(rootDir, true); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		err := filepath.WalkDir(rootDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !d.IsDir() {
+				relPath, err := filepath.Rel(pluginsDir, p)
+				if err != nil {
+					return err
+				}
+				result.Kept = append(result.Kept, filepath.ToSlash(relPath))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list remaining files of %s", root)
+		}
+	}
+	sort.Strings(result.Kept)
+
+	for _, edit := range edits {
+		if err := edit.apply(tx); err != nil {
+			return nil, errors.Wrap(err, "failed to edit file")
+		}
+		result.Reverted = append(result.Reverted, edit.Path)
+	}
+
+	if err := tx.backup(pristineDir(pluginsDir), true); err != nil {
+		return nil, err
+	}
+	if err := tx.backup(ManifestPath(pluginsDir), true); err != nil {
+		return nil, err
+	}
+	removeEmptyParents(pluginsDir, ManifestPath(pluginsDir))
+
+	return result, nil
+}
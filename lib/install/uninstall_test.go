@@ -0,0 +1,64 @@
+package install
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUninstallKeepsModifiedFiles(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/a.txt": "a", "Wwise/b.txt": "b", "WwiseNiagara/c.txt": "c"}, true)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/b.txt": "b, patched", "Wwise/extra.txt": "extra", "Other/d.txt": "d"})
+
+	result, err := Uninstall(pluginsDir, mustReadManifest(t, pluginsDir), nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Removed != 2 {
+		t.Errorf("removed %d files, want 2", result.Removed)
+	}
+	if want := []string{"Wwise/b.txt", "Wwise/extra.txt"}; !reflect.DeepEqual(result.Kept, want) {
+		t.Errorf("kept %v, want %v", result.Kept, want)
+	}
+	assertFiles(t, pluginsDir, map[string]string{
+		"Wwise/a.txt":        "",
+		"Wwise/b.txt":        "b, patched",
+		"Wwise/extra.txt":    "extra",
+		"WwiseNiagara/c.txt": "",
+		"Other/d.txt":        "d",
+	})
+	if _, err := os.Stat(ManifestPath(pluginsDir)); !os.IsNotExist(err) {
+		t.Error("install manifest left after uninstalling")
+	}
+	if _, err := os.Stat(pristineDir(pluginsDir)); !os.IsNotExist(err) {
+		t.Error("pristine copies left after uninstalling")
+	}
+
+	// Uninstalling goes through a transaction like installing
+	if err := Rollback(pluginsDir); err != nil {
+		t.Fatal(err)
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Wwise/a.txt": "a", "Wwise/b.txt": "b, patched", "WwiseNiagara/c.txt": "c"})
+	assertFiles(t, pristineDir(pluginsDir), map[string]string{"Wwise/a.txt": "a"})
+	mustReadManifest(t, pluginsDir)
+}
+
+func TestUninstallForce(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/a.txt": "a", "Wwise/b.txt": "b"}, true)
+	writeFiles(t, pluginsDir, map[string]string{"Wwise/b.txt": "b, patched", "Wwise/extra.txt": "extra", "Other/d.txt": "d"})
+
+	result, err := Uninstall(pluginsDir, mustReadManifest(t, pluginsDir), nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Kept) != 0 {
+		t.Errorf("kept %v, want nothing", result.Kept)
+	}
+	if _, err := os.Stat(filepath.Join(pluginsDir, "Wwise")); !os.IsNotExist(err) {
+		t.Error("Wwise left after uninstalling with force")
+	}
+	assertFiles(t, pluginsDir, map[string]string{"Other/d.txt": "d"})
+}
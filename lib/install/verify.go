@@ -0,0 +1,67 @@
+package install
+
+import (
+	"fmt"
+	"io"
+)
+
+// Verification lists how the files in the plugins directory differ from the files of an installation.
+type Verification struct {
+	Missing    []string
+	Modified   []string
+	Unexpected []string
+	// Edits are the files outside the installation that are not set up for it, such as the project file.
+	Edits []string
+}
+
+// OK reports whether the installation matches, including the files outside it that it sets up.
+func (v *Verification) OK() bool {
+	return len(v.Missing) == 0 && len(v.Modified) == 0 && len(v.Unexpected) == 0 && len(v.Edits) == 0
+}
+
+func (v *Verification) PrintSummary(w io.Writer) {
+	sections := []struct {
+		title string
+		files []string
+	}{
+		{"Missing", v.Missing},
+		{"Modified", v.Modified},
+		{"Unexpected", v.Unexpected},
+		{"Not set up for the installation", v.Edits},
+	}
+	for _, section := range sections {
+		if len(section.files) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", section.title)
+		for _, file := range section.files {
+			fmt.Fprintf(w, "  %s\n", file)
+		}
+	}
+
+	if v.OK() {
+		fmt.Fprintln(w, "Installation matches")
+	} else {
+		fmt.Fprintf(w, "Installation does not match: %d missing, %d modified, %d unexpected files, %d files not set up\n", len(v.Missing), len(v.Modified), len(v.Unexpected), len(v.Edits))
+	}
+}
+
+// Verify compares the plugins directory against the installation the plan was made for, without changing anything.
+// Linked files are compared by the content they reference.
+func (p *Plan) Verify() *Verification {
+	v := &Verification{}
+	for _, op := range p.Operations {
+		switch op.Action {
+		case ActionCreate:
+			v.Missing = append(v.Missing, op.Path)
+		case ActionOverwrite, ActionMerge, ActionConflict, ActionKeep:
+			v.Modified = append(v.Modified, op.Path)
+		case ActionDelete, ActionExtra:
+			v.Unexpected = append(v.Unexpected, op.Path)
+		}
+	}
+	for _, edit := range p.Edits {
+		v.Edits = append(v.Edits, edit.Path)
+	}
+	return v
+}
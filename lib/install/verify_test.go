@@ -0,0 +1,35 @@
+package install
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestVerificationEdits(t *testing.T) {
+	pluginsDir := newTestInstall(t)
+	install(t, pluginsDir, "1", map[string]string{"Wwise/a.txt": "a"}, true)
+
+	plan, err := NewPlan(pluginsDir, sourceFiles(t, map[string]string{"Wwise/a.txt": "a"}, true), Manifest{IntegrationVersion: "1"}, mustReadManifest(t, pluginsDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification := plan.Verify(); !verification.OK() {
+		t.Errorf("verification of a matching installation fails: %+v", verification)
+	}
+
+	// A project file reverted by hand has to be set up again
+	plan.Edits = append(plan.Edits, FileEdit{Path: "Game.uproject"})
+	verification := plan.Verify()
+	if verification.OK() {
+		t.Error("verification passes with a file not set up for the installation")
+	}
+
+	var out bytes.Buffer
+	verification.PrintSummary(&out)
+	for _, line := range []string{"Not set up for the installation:\n  Game.uproject\n", "1 files not set up"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("summary misses %q:\n%s", line, out.String())
+		}
+	}
+}
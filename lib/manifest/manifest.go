@@ -0,0 +1,78 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultFileName = "wwise.yaml"
+
+// DefaultEngine is the engine integration of manifests that do not declare one.
+const DefaultEngine = "unreal"
+
+type Manifest struct {
+	// Engine is the name of the engine integration of the project, DefaultEngine if empty.
+	Engine             string   `yaml:"engine,omitempty"`
+	Project            string   `yaml:"project,omitempty"`
+	IntegrationVersion string   `yaml:"integrationVersion"`
+	SdkVersion         string   `yaml:"sdkVersion,omitempty"`
+	Platforms          []string `yaml:"platforms,omitempty"`
+	Configurations     []string `yaml:"configurations,omitempty"`
+	Plugins            []string `yaml:"plugins,omitempty"`
+	WwiseProject       string   `yaml:"wwiseProject,omitempty"`
+
+	path string
+}
+
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest")
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal manifest")
+	}
+
+	if m.IntegrationVersion == "" {
+		return nil, errors.New("manifest does not declare an integrationVersion")
+	}
+
+	m.path = path
+	return &m, nil
+}
+
+// EngineName returns the name of the engine integration of the project.
+func (m *Manifest) EngineName() string {
+	if m.Engine == "" {
+		return DefaultEngine
+	}
+	return m.Engine
+}
+
+// ProjectPath returns the project the manifest applies to. A relative project path is resolved
+// against the manifest's directory, and an empty one is the manifest's directory.
+func (m *Manifest) ProjectPath() string {
+	manifestDir := filepath.Dir(m.path)
+
+	if m.Project == "" {
+		return manifestDir
+	}
+	if filepath.IsAbs(m.Project) {
+		return m.Project
+	}
+	return filepath.Join(manifestDir, m.Project)
+}
+
+// WwiseProjectFile returns the .wproj whose settings are written to the project, or an empty string if there is none.
+// A relative path is resolved against the manifest's directory.
+func (m *Manifest) WwiseProjectFile() string {
+	if m.WwiseProject == "" || filepath.IsAbs(m.WwiseProject) {
+		return m.WwiseProject
+	}
+	return filepath.Join(filepath.Dir(m.path), m.WwiseProject)
+}
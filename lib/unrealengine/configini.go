@@ -0,0 +1,262 @@
+package unrealengine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// UE config files use duplicate keys and +/- array syntax, so they are edited line by line
+// to keep everything else, including comments, exactly as it was.
+
+func splitConfigLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func configSectionName(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+		return trimmed[1 : len(trimmed)-1], true
+	}
+	return "", false
+}
+
+// ConfigSetting is a key of a section of a config file in the project's Config directory.
+// Keys starting with + are array elements, which are added unless the same element is already there.
+type ConfigSetting struct {
+	File    string `json:"file"`
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+// ConfigChange is how a setting changed its config file, so that the change can be reverted.
+type ConfigChange struct {
+	ConfigSetting
+	// Previous is the value the key had, or nil if it was not set. Array elements are always added.
+	Previous *string `json:"previous,omitempty"`
+	// AddedSection is set if the section was added for the setting.
+	AddedSection bool `json:"addedSection,omitempty"`
+}
+
+func configLineKey(line string) string {
+	key, _, found := strings.Cut(strings.TrimSpace(line), "=")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
+
+func configLineValue(line string) string {
+	_, value, _ := strings.Cut(strings.TrimSpace(line), "=")
+	return strings.TrimSpace(value)
+}
+
+// withConfigLineValue returns the line with its value replaced, keeping the key, the spacing after = and the line ending as they were.
+func withConfigLineValue(line string, value string) string {
+	content := strings.TrimRight(line, "\r\n")
+	eol := line[len(content):]
+	key, rest, _ := strings.Cut(content, "=")
+	spacing := rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+	return key + "=" + spacing + value + eol
+}
+
+func configEOL(content string) string {
+	if strings.Contains(content, "\r\n") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// keepFinalEOL returns the edited content without a final line ending if the content had none.
+func keepFinalEOL(content string, edited string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		return strings.TrimRight(edited, "\r\n")
+	}
+	return edited
+}
+
+// configSectionLines returns the line of the section header, or -1 if there is no such section, and the end of the section.
+func configSectionLines(lines []string, section string) (int, int) {
+	start, end := -1, len(lines)
+	for i, line := range lines {
+		name, ok := configSectionName(line)
+		if !ok {
+			continue
+		}
+		if start >= 0 {
+			end = i
+			break
+		}
+		// UE matches section names and keys regardless of case
+		if strings.EqualFold(name, section) {
+			start = i
+		}
+	}
+	return start, end
+}
+
+// setConfigValue sets a key of a section, adding the section at the end if it does not exist.
+// It returns how the content was changed, or nil if the key was already set.
+func setConfigValue(content string, setting ConfigSetting) (string, *ConfigChange) {
+	eol := configEOL(content)
+	newLine := setting.Key + "=" + setting.Value + eol
+
+	lines := splitConfigLines(content)
+	start, end := configSectionLines(lines, setting.Section)
+
+	if start < 0 {
+		var out strings.Builder
+		out.WriteString(content)
+		if len(lines) > 0 {
+			// Separate the new section from the previous one with an empty line
+			lastLine := lines[len(lines)-1]
+			if !strings.HasSuffix(lastLine, "\n") {
+				out.WriteString(eol)
+			}
+			if strings.TrimSpace(lastLine) != "" {
+				out.WriteString(eol)
+			}
+		}
+		out.WriteString("[" + setting.Section + "]" + eol)
+		out.WriteString(newLine)
+		return keepFinalEOL(content, out.String()), &ConfigChange{ConfigSetting: setting, AddedSection: true}
+	}
+
+	isArray := strings.HasPrefix(setting.Key, "+")
+	insertAt := start + 1
+	for i := start + 1; i < end; i++ {
+		if strings.TrimSpace(lines[i]) != "" {
+			insertAt = i + 1
+		}
+		if !strings.EqualFold(configLineKey(lines[i]), setting.Key) {
+			continue
+		}
+		previous := configLineValue(lines[i])
+		if previous == setting.Value {
+			return content, nil
+		}
+		if !isArray {
+			lines[i] = withConfigLineValue(lines[i], setting.Value)
+			return strings.Join(lines, ""), &ConfigChange{ConfigSetting: setting, Previous: &previous}
+		}
+	}
+
+	if insertAt > 0 && !strings.HasSuffix(lines[insertAt-1], "\n") {
+		lines[insertAt-1] += eol
+	}
+	lines = append(lines[:insertAt], append([]string{newLine}, lines[insertAt:]...)...)
+	return keepFinalEOL(content, strings.Join(lines, "")), &ConfigChange{ConfigSetting: setting}
+}
+
+// revertConfigValue reverts a change of setConfigValue, unless the key was changed again since.
+// A section that was added for the setting is removed if nothing else was added to it.
+func revertConfigValue(content string, change ConfigChange) (string, bool) {
+	lines := splitConfigLines(content)
+	start, end := configSectionLines(lines, change.Section)
+	if start < 0 {
+		return content, false
+	}
+
+	found := false
+	for i := start + 1; i < end; i++ {
+		if !strings.EqualFold(configLineKey(lines[i]), change.Key) || configLineValue(lines[i]) != change.Value {
+			continue
+		}
+		if change.Previous == nil {
+			lines = append(lines[:i], lines[i+1:]...)
+			end--
+		} else {
+			lines[i] = withConfigLineValue(lines[i], *change.Previous)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return content, false
+	}
+
+	if change.AddedSection && strings.TrimSpace(strings.Join(lines[start+1:end], "")) == "" {
+		lines = append(lines[:start], lines[end:]...)
+		// Remove the empty line that separated the section from the previous one
+		if start > 0 && start == len(lines) && strings.TrimSpace(lines[start-1]) == "" {
+			lines = lines[:start-1]
+		}
+	}
+	return keepFinalEOL(content, strings.Join(lines, "")), true
+}
+
+// editConfigFiles edits the config files of the project in order, given by path relative to the Config directory.
+// It returns the new content of the files that were changed, by path.
+func editConfigFiles(projectDir string, files []string, edit func(i int, content string) (string, bool)) (map[string][]byte, error) {
+	contents := make(map[string]string)
+	changed := make(map[string]bool)
+	for i, file := range files {
+		path := filepath.Join(projectDir, "Config", filepath.FromSlash(file))
+		content, ok := contents[path]
+		if !ok {
+			data, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, errors.Wrapf(err, "failed to read %s", path)
+			}
+			content = string(data)
+		}
+
+		content, fileChanged := edit(i, content)
+		contents[path] = content
+		changed[path] = changed[path] || fileChanged
+	}
+
+	changedFiles := make(map[string][]byte)
+	for path, content := range contents {
+		if changed[path] {
+			changedFiles[path] = []byte(content)
+		}
+	}
+	return changedFiles, nil
+}
+
+// SetConfigSettings returns the new content of the config files of the project changed by the settings, by path,
+// and how the settings changed them. Settings that are already set leave their file unchanged,
+// and everything else in the files is kept as it was.
+func SetConfigSettings(projectDir string, settings []ConfigSetting) (map[string][]byte, []ConfigChange, error) {
+	var changes []ConfigChange
+	files := make([]string, len(settings))
+	for i, setting := range settings {
+		files[i] = setting.File
+	}
+
+	changedFiles, err := editConfigFiles(projectDir, files, func(i int, content string) (string, bool) {
+		content, change := setConfigValue(content, settings[i])
+		if change == nil {
+			return content, false
+		}
+		changes = append(changes, *change)
+		return content, true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return changedFiles, changes, nil
+}
+
+// RevertConfigSettings returns the new content of the config files of the project with the changes reverted, last first, by path.
+// Keys that were changed again since are left as they are.
+func RevertConfigSettings(projectDir string, changes []ConfigChange) (map[string][]byte, error) {
+	reversed := make([]ConfigChange, len(changes))
+	files := make([]string, len(changes))
+	for i := range changes {
+		reversed[i] = changes[len(changes)-1-i]
+		files[i] = reversed[i].File
+	}
+
+	return editConfigFiles(projectDir, files, func(i int, content string) (string, bool) {
+		return revertConfigValue(content, reversed[i])
+	})
+}
@@ -0,0 +1,236 @@
+package unrealengine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const akSettingsSection = "/Script/AkAudio.AkSettings"
+
+func TestSetConfigValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		setting  ConfigSetting
+		want     string
+		previous string
+		// unchanged is set if the setting is already set
+		unchanged bool
+	}{
+		{
+			name:    "empty file",
+			content: "",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "WwiseProjectPath", Value: "(FilePath=\"Game.wproj\")"},
+			want:    "[/Script/AkAudio.AkSettings]\nWwiseProjectPath=(FilePath=\"Game.wproj\")\n",
+		},
+		{
+			name:    "missing section",
+			content: "[/Script/EngineSettings.GeneralProjectSettings]\nProjectID=1234\n",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "WwiseProjectPath", Value: "(FilePath=\"Game.wproj\")"},
+			want:    "[/Script/EngineSettings.GeneralProjectSettings]\nProjectID=1234\n\n[/Script/AkAudio.AkSettings]\nWwiseProjectPath=(FilePath=\"Game.wproj\")\n",
+		},
+		{
+			name:    "missing section after an empty line, without a final line ending",
+			content: "[Other]\nA=1\n\n; comment",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "B", Value: "2"},
+			want:    "[Other]\nA=1\n\n; comment\n\n[/Script/AkAudio.AkSettings]\nB=2",
+		},
+		{
+			name:    "missing key",
+			content: "[/Script/AkAudio.AkSettings]\nA=1\n\n[Other]\nB=2\n",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "C", Value: "3"},
+			want:    "[/Script/AkAudio.AkSettings]\nA=1\nC=3\n\n[Other]\nB=2\n",
+		},
+		{
+			name:      "key already set",
+			content:   "[/Script/AkAudio.AkSettings]\nA=1\n",
+			setting:   ConfigSetting{Section: akSettingsSection, Key: "A", Value: "1"},
+			want:      "[/Script/AkAudio.AkSettings]\nA=1\n",
+			unchanged: true,
+		},
+		{
+			name:     "key with a different value",
+			content:  "[/Script/AkAudio.AkSettings]\nA = 1\nB=2\n",
+			setting:  ConfigSetting{Section: akSettingsSection, Key: "A", Value: "3"},
+			want:     "[/Script/AkAudio.AkSettings]\nA = 3\nB=2\n",
+			previous: "1",
+		},
+		{
+			name:      "keys and sections regardless of case",
+			content:   "[/script/akaudio.aksettings]\nwwiseprojectpath=(FilePath=\"Game.wproj\")\n",
+			setting:   ConfigSetting{Section: akSettingsSection, Key: "WwiseProjectPath", Value: "(FilePath=\"Game.wproj\")"},
+			want:      "[/script/akaudio.aksettings]\nwwiseprojectpath=(FilePath=\"Game.wproj\")\n",
+			unchanged: true,
+		},
+		{
+			name:     "duplicate keys set the first one",
+			content:  "[/Script/AkAudio.AkSettings]\nA=1\nA=2\n",
+			setting:  ConfigSetting{Section: akSettingsSection, Key: "A", Value: "3"},
+			want:     "[/Script/AkAudio.AkSettings]\nA=3\nA=2\n",
+			previous: "1",
+		},
+		{
+			name:     "same key in another section",
+			content:  "[Other]\nA=1\n[/Script/AkAudio.AkSettings]\nB=2\n",
+			setting:  ConfigSetting{Section: akSettingsSection, Key: "A", Value: "1"},
+			want:     "[Other]\nA=1\n[/Script/AkAudio.AkSettings]\nB=2\nA=1\n",
+			previous: "",
+		},
+		{
+			name:    "array element",
+			content: "[/Script/Engine.Engine]\n+ActiveGameNameRedirects=(OldGameName=\"A\",NewGameName=\"/Script/A\")\n\n[Other]\n",
+			setting: ConfigSetting{Section: "/Script/Engine.Engine", Key: "+ActiveGameNameRedirects", Value: "(OldGameName=\"B\",NewGameName=\"/Script/B\")"},
+			want:    "[/Script/Engine.Engine]\n+ActiveGameNameRedirects=(OldGameName=\"A\",NewGameName=\"/Script/A\")\n+ActiveGameNameRedirects=(OldGameName=\"B\",NewGameName=\"/Script/B\")\n\n[Other]\n",
+		},
+		{
+			name:      "array element already there",
+			content:   "[/Script/Engine.Engine]\n+Paths=A\n+Paths=B\n",
+			setting:   ConfigSetting{Section: "/Script/Engine.Engine", Key: "+Paths", Value: "B"},
+			want:      "[/Script/Engine.Engine]\n+Paths=A\n+Paths=B\n",
+			unchanged: true,
+		},
+		{
+			name:    "array element with a plain key of the same name",
+			content: "[/Script/Engine.Engine]\nPaths=B\n",
+			setting: ConfigSetting{Section: "/Script/Engine.Engine", Key: "+Paths", Value: "B"},
+			want:    "[/Script/Engine.Engine]\nPaths=B\n+Paths=B\n",
+		},
+		{
+			name:    "commented out key",
+			content: "[/Script/AkAudio.AkSettings]\n;A=1\n",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "A", Value: "2"},
+			want:    "[/Script/AkAudio.AkSettings]\n;A=1\nA=2\n",
+		},
+		{
+			name:    "CRLF missing section",
+			content: "[Other]\r\nA=1\r\n",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "B", Value: "2"},
+			want:    "[Other]\r\nA=1\r\n\r\n[/Script/AkAudio.AkSettings]\r\nB=2\r\n",
+		},
+		{
+			name:    "CRLF missing key",
+			content: "[/Script/AkAudio.AkSettings]\r\nA=1\r\n\r\n[Other]\r\n",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "B", Value: "2"},
+			want:    "[/Script/AkAudio.AkSettings]\r\nA=1\r\nB=2\r\n\r\n[Other]\r\n",
+		},
+		{
+			name:     "CRLF key with a different value",
+			content:  "[/Script/AkAudio.AkSettings]\r\nA=1\r\nB=2\r\n",
+			setting:  ConfigSetting{Section: akSettingsSection, Key: "A", Value: "3"},
+			want:     "[/Script/AkAudio.AkSettings]\r\nA=3\r\nB=2\r\n",
+			previous: "1",
+		},
+		{
+			name:    "missing key at the end of a file without a final line ending",
+			content: "[/Script/AkAudio.AkSettings]\r\nA=1",
+			setting: ConfigSetting{Section: akSettingsSection, Key: "B", Value: "2"},
+			want:    "[/Script/AkAudio.AkSettings]\r\nA=1\r\nB=2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content, change := setConfigValue(test.content, test.setting)
+			if content != test.want {
+				t.Errorf("set as:\n%q\nwant:\n%q", content, test.want)
+			}
+			if test.unchanged {
+				if change != nil {
+					t.Errorf("change %+v for a setting already set", change)
+				}
+				return
+			}
+			if change == nil {
+				t.Fatal("no change")
+			}
+			if previous := change.Previous; (previous == nil) != (test.previous == "") || (previous != nil && *previous != test.previous) {
+				t.Errorf("previous value %v, want %q", previous, test.previous)
+			}
+
+			// Reverting gives back the original content
+			reverted, changed := revertConfigValue(content, *change)
+			if !changed {
+				t.Fatal("reverting made no changes")
+			}
+			if reverted != test.content {
+				t.Errorf("reverted as:\n%q\nwant:\n%q", reverted, test.content)
+			}
+		})
+	}
+}
+
+func TestRevertConfigValueChangedSince(t *testing.T) {
+	content, change := setConfigValue("[/Script/AkAudio.AkSettings]\nA=1\n", ConfigSetting{Section: akSettingsSection, Key: "A", Value: "2"})
+	content = strings.Replace(content, "A=2", "A=3", 1)
+
+	// A key that was changed again is the user's now
+	if reverted, changed := revertConfigValue(content, *change); changed || reverted != content {
+		t.Errorf("reverted a key changed since to:\n%q", reverted)
+	}
+}
+
+func TestRevertConfigValueKeepsAddedSectionWithOtherKeys(t *testing.T) {
+	content, change := setConfigValue("[Other]\nA=1\n", ConfigSetting{Section: akSettingsSection, Key: "B", Value: "2"})
+	content += "C=3\n"
+
+	reverted, changed := revertConfigValue(content, *change)
+	if want := "[Other]\nA=1\n\n[/Script/AkAudio.AkSettings]\nC=3\n"; !changed || reverted != want {
+		t.Errorf("reverted as:\n%q\nwant:\n%q", reverted, want)
+	}
+}
+
+func TestSetConfigSettings(t *testing.T) {
+	projectDir := t.TempDir()
+	gameIni := filepath.Join(projectDir, "Config", "DefaultGame.ini")
+	engineIni := filepath.Join(projectDir, "Config", "DefaultEngine.ini")
+	original := "[/Script/EngineSettings.GeneralProjectSettings]\r\nProjectID=1234\r\n"
+	if err := os.MkdirAll(filepath.Dir(gameIni), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(gameIni, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := []ConfigSetting{
+		{File: "DefaultGame.ini", Section: akSettingsSection, Key: "WwiseProjectPath", Value: "(FilePath=\"Game.wproj\")"},
+		{File: "DefaultGame.ini", Section: akSettingsSection, Key: "RootOutputPath", Value: "(Path=\"WwiseAudio\")"},
+		{File: "DefaultEngine.ini", Section: "/Script/Engine.Engine", Key: "+ActiveClassRedirects", Value: "(OldClassName=\"A\",NewClassName=\"B\")"},
+	}
+	files, changes, err := SetConfigSettings(projectDir, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("%d changes, want 3", len(changes))
+	}
+	wantGame := original + "\r\n[/Script/AkAudio.AkSettings]\r\nWwiseProjectPath=(FilePath=\"Game.wproj\")\r\nRootOutputPath=(Path=\"WwiseAudio\")\r\n"
+	if string(files[gameIni]) != wantGame {
+		t.Errorf("DefaultGame.ini set as:\n%q\nwant:\n%q", files[gameIni], wantGame)
+	}
+	if wantEngine := "[/Script/Engine.Engine]\n+ActiveClassRedirects=(OldClassName=\"A\",NewClassName=\"B\")\n"; string(files[engineIni]) != wantEngine {
+		t.Errorf("DefaultEngine.ini set as:\n%q\nwant:\n%q", files[engineIni], wantEngine)
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Settings that are set change nothing
+	if files, changes, err := SetConfigSettings(projectDir, settings); err != nil || len(files) != 0 || len(changes) != 0 {
+		t.Errorf("setting again changed %v, %v, %v", files, changes, err)
+	}
+
+	reverted, err := RevertConfigSettings(projectDir, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(reverted[gameIni]) != original {
+		t.Errorf("DefaultGame.ini reverted as:\n%q\nwant:\n%q", reverted[gameIni], original)
+	}
+	if content, ok := reverted[engineIni]; !ok || len(content) != 0 {
+		t.Errorf("DefaultEngine.ini reverted as %q, want empty", content)
+	}
+}
@@ -0,0 +1,42 @@
+package unrealengine
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// ListEngines returns the root of every registered engine, by association identifier.
+func ListEngines() (map[string]string, error) {
+	engines, _, err := listEngines()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list engines")
+	}
+	return engines, nil
+}
+
+// RegisterEngine registers the engine at engineRoot, so that projects can be associated with it by identifier.
+func RegisterEngine(identifier string, engineRoot string) error {
+	engineRoot, err := filepath.Abs(engineRoot)
+	if err != nil {
+		return errors.Wrap(err, "failed to get absolute engine root")
+	}
+
+	if !isEngineRoot(engineRoot) {
+		return errors.Errorf("invalid engine root %s: failed to find the engine version files", engineRoot)
+	}
+
+	if err := registerEngine(identifier, engineRoot); err != nil {
+		return errors.Wrap(err, "failed to register engine")
+	}
+	return nil
+}
+
+// UnregisterEngine removes the registration of an engine. It returns false if no engine was registered with the identifier.
+func UnregisterEngine(identifier string) (bool, error) {
+	removed, err := unregisterEngine(identifier)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to unregister engine")
+	}
+	return removed, nil
+}
@@ -0,0 +1,122 @@
+package unrealengine
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EngineVersionSourceOverride is the source of versions given by the user instead of read from the engine.
+const EngineVersionSourceOverride = "override"
+
+var (
+	buildVersionFile  = filepath.Join("Engine", "Build", "Build.version")
+	versionHeaderFile = filepath.Join("Engine", "Source", "Runtime", "Launch", "Resources", "Version.h")
+)
+
+// EngineVersion is the version of an engine, and where it was read from.
+type EngineVersion struct {
+	Major  int
+	Minor  int
+	Patch  int
+	Source string
+}
+
+func (v EngineVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// ParseEngineVersion parses a major.minor[.patch] version given by the user.
+func ParseEngineVersion(version string) (EngineVersion, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return EngineVersion{}, errors.Errorf("invalid engine version %s, expected major.minor[.patch]", version)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return EngineVersion{}, errors.Errorf("invalid engine version %s, expected major.minor[.patch]", version)
+		}
+		numbers[i] = number
+	}
+
+	return EngineVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2], Source: EngineVersionSourceOverride}, nil
+}
+
+// GetEngineVersion reads the version of the engine from Build.version. Engines that do not ship Build.version,
+// or whose Build.version disagrees with the version the engine is compiled with, use the version from Version.h.
+func GetEngineVersion(enginePath string) (EngineVersion, error) {
+	headerVersion, headerErr := readVersionHeader(filepath.Join(enginePath, versionHeaderFile))
+
+	buildVersion, err := GetEngineVersionData(enginePath)
+	if err != nil {
+		if headerErr != nil {
+			return EngineVersion{}, errors.Errorf("failed to read engine version from %s (%s) or %s (%s)",
+				filepath.Join(enginePath, buildVersionFile), err.Error(), filepath.Join(enginePath, versionHeaderFile), headerErr.Error())
+		}
+		return headerVersion, nil
+	}
+
+	version := EngineVersion{
+		Major:  buildVersion.MajorVersion,
+		Minor:  buildVersion.MinorVersion,
+		Patch:  buildVersion.PatchVersion,
+		Source: buildVersionFile,
+	}
+
+	if headerErr == nil && (headerVersion.Major != version.Major || headerVersion.Minor != version.Minor) {
+		headerVersion.Source = fmt.Sprintf("%s, %s says %s", versionHeaderFile, buildVersionFile, version)
+		return headerVersion, nil
+	}
+
+	return version, nil
+}
+
+// readVersionHeader reads the ENGINE_*_VERSION defines of Version.h.
+func readVersionHeader(path string) (EngineVersion, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return EngineVersion{}, errors.Wrap(err, "failed to open version header")
+	}
+	defer file.Close()
+
+	version := EngineVersion{Major: -1, Minor: -1, Source: versionHeaderFile}
+	defines := map[string]*int{
+		"ENGINE_MAJOR_VERSION": &version.Major,
+		"ENGINE_MINOR_VERSION": &version.Minor,
+		"ENGINE_PATCH_VERSION": &version.Patch,
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "#define" {
+			continue
+		}
+		value, ok := defines[fields[1]]
+		if !ok {
+			continue
+		}
+		number, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return EngineVersion{}, errors.Wrapf(err, "failed to parse %s", fields[1])
+		}
+		*value = number
+	}
+	if err := scanner.Err(); err != nil {
+		return EngineVersion{}, errors.Wrap(err, "failed to read version header")
+	}
+
+	if version.Major < 0 || version.Minor < 0 {
+		return EngineVersion{}, errors.New("failed to find ENGINE_MAJOR_VERSION and ENGINE_MINOR_VERSION")
+	}
+
+	return version, nil
+}
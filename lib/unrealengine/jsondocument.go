@@ -0,0 +1,250 @@
+package unrealengine
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// jsonField is a field of a JSON object. Objects are kept as ordered fields, so that editing UE JSON files
+// (.uproject, .uplugin) keeps the original key order.
+type jsonField struct {
+	Key   string
+	Value interface{}
+}
+
+type jsonObject []jsonField
+
+func (o jsonObject) get(key string) (interface{}, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (o *jsonObject) set(key string, value interface{}) {
+	for i, field := range *o {
+		if field.Key == key {
+			(*o)[i].Value = value
+			return
+		}
+	}
+	*o = append(*o, jsonField{Key: key, Value: value})
+}
+
+func (o *jsonObject) remove(key string) {
+	for i, field := range *o {
+		if field.Key == key {
+			*o = append((*o)[:i], (*o)[i+1:]...)
+			return
+		}
+	}
+}
+
+// jsonDocument is a JSON file that is written back with the same encoding, BOM, indentation and line endings it was read with.
+type jsonDocument struct {
+	Root jsonObject
+
+	// encoding is the UTF-16 encoding of the file, or nil if it is UTF-8.
+	encoding        encoding.Encoding
+	bom             bool
+	indent          string
+	eol             string
+	trailingNewline bool
+}
+
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+func readJSONDocument(path string) (*jsonDocument, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	doc := &jsonDocument{
+		bom:    bytes.HasPrefix(data, utf8BOM),
+		indent: "\t",
+		eol:    "\n",
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	// The UE editor writes UTF-16 files, with a BOM, if they contain characters outside of ANSI
+	switch {
+	case bytes.HasPrefix(data, utf16LEBOM):
+		doc.encoding = unicode.UTF16(unicode.LittleEndian, unicode.UseBOM)
+	case bytes.HasPrefix(data, utf16BEBOM):
+		doc.encoding = unicode.UTF16(unicode.BigEndian, unicode.UseBOM)
+	}
+	if doc.encoding != nil {
+		data, err = doc.encoding.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode file")
+		}
+	}
+
+	if bytes.Contains(data, []byte("\r\n")) {
+		doc.eol = "\r\n"
+	}
+	doc.trailingNewline = bytes.HasSuffix(data, []byte("\n"))
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			doc.indent = line[:len(line)-len(trimmed)]
+			break
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	root, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse file")
+	}
+
+	object, ok := root.(jsonObject)
+	if !ok {
+		return nil, errors.New("file is not a JSON object")
+	}
+	doc.Root = object
+
+	return doc, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := jsonObject{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, errors.New("expected object key")
+			}
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, jsonField{Key: key, Value: value})
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeJSONValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	default:
+		return token, nil
+	}
+}
+
+func (d *jsonDocument) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if d.bom {
+		buf.Write(utf8BOM)
+	}
+	if err := d.encodeValue(&buf, d.Root, 0); err != nil {
+		return nil, errors.Wrap(err, "failed to encode file")
+	}
+	if d.trailingNewline {
+		buf.WriteString(d.eol)
+	}
+	if d.encoding != nil {
+		content, err := d.encoding.NewEncoder().Bytes(buf.Bytes())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode file")
+		}
+		return content, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeValue writes a value the way UE's JSON writer pretty prints it: every object field and array element on its own line.
+func (d *jsonDocument) encodeValue(w io.Writer, value interface{}, depth int) error {
+	newline := d.eol + strings.Repeat(d.indent, depth+1)
+	closing := d.eol + strings.Repeat(d.indent, depth)
+
+	switch v := value.(type) {
+	case jsonObject:
+		if len(v) == 0 {
+			_, err := io.WriteString(w, "{}")
+			return err
+		}
+		io.WriteString(w, "{")
+		for i, field := range v {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			io.WriteString(w, newline)
+			if err := encodeJSONScalar(w, field.Key); err != nil {
+				return err
+			}
+			io.WriteString(w, ": ")
+			if err := d.encodeValue(w, field.Value, depth+1); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, closing+"}")
+		return err
+	case []interface{}:
+		if len(v) == 0 {
+			_, err := io.WriteString(w, "[]")
+			return err
+		}
+		io.WriteString(w, "[")
+		for i, element := range v {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			io.WriteString(w, newline)
+			if err := d.encodeValue(w, element, depth+1); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(w, closing+"]")
+		return err
+	default:
+		return encodeJSONScalar(w, v)
+	}
+}
+
+func encodeJSONScalar(w io.Writer, value interface{}) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	_, err := w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return err
+}
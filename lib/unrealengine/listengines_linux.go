@@ -9,24 +9,43 @@ import (
 	"gopkg.in/ini.v1"
 )
 
-func listEngines() (map[string]string, error) {
+const installationsSectionName = "Installations"
+
+func installsFilePath() (string, error) {
 	userConfig, err := os.UserConfigDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user config dir: %w", err)
+		return "", fmt.Errorf("failed to get user config dir: %w", err)
+	}
+	return filepath.Join(userConfig, "Epic", "UnrealEngine", "Install.ini"), nil
+}
+
+// loadInstallsFile loads the launcher installs file, or an empty one if it does not exist.
+func loadInstallsFile(installsFile string) (*ini.File, error) {
+	if _, err := os.Stat(installsFile); os.IsNotExist(err) {
+		return ini.Empty(), nil
 	}
 
-	installsFile := filepath.Join(userConfig, "Epic", "UnrealEngine", "Install.ini")
 	cfg, err := ini.Load(installsFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load installs file: %w", err)
 	}
+	return cfg, nil
+}
 
-	installationsSection := cfg.Section("Installations")
-	if installationsSection == nil {
-		return nil, fmt.Errorf("failed to find installations section")
+// listEngines returns the engines registered in the launcher installs file, and the locations it searched.
+func listEngines() (map[string]string, []string, error) {
+	installsFile, err := installsFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := loadInstallsFile(installsFile)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	engines := make(map[string]string)
+	installationsSection := cfg.Section(installationsSectionName)
 	for _, key := range installationsSection.KeyStrings() {
 		enginePath := installationsSection.Key(key).String()
 		engineVersion := key
@@ -35,5 +54,56 @@ func listEngines() (map[string]string, error) {
 		}
 		engines[engineVersion] = enginePath
 	}
-	return engines, nil
+	return engines, []string{installsFile}, nil
+}
+
+func registerEngine(identifier string, engineRoot string) error {
+	installsFile, err := installsFilePath()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadInstallsFile(installsFile)
+	if err != nil {
+		return err
+	}
+
+	cfg.Section(installationsSectionName).Key(identifier).SetValue(engineRoot)
+
+	if err := os.MkdirAll(filepath.Dir(installsFile), 0755); err != nil {
+		return fmt.Errorf("failed to create installs file directory: %w", err)
+	}
+	if err := cfg.SaveTo(installsFile); err != nil {
+		return fmt.Errorf("failed to save installs file: %w", err)
+	}
+	return nil
+}
+
+func unregisterEngine(identifier string) (bool, error) {
+	installsFile, err := installsFilePath()
+	if err != nil {
+		return false, err
+	}
+
+	cfg, err := loadInstallsFile(installsFile)
+	if err != nil {
+		return false, err
+	}
+
+	installationsSection := cfg.Section(installationsSectionName)
+	removed := false
+	for _, key := range installationsSection.KeyStrings() {
+		if strings.EqualFold(key, identifier) || strings.EqualFold(key, "UE_"+identifier) {
+			installationsSection.DeleteKey(key)
+			removed = true
+		}
+	}
+	if !removed {
+		return false, nil
+	}
+
+	if err := cfg.SaveTo(installsFile); err != nil {
+		return false, fmt.Errorf("failed to save installs file: %w", err)
+	}
+	return true, nil
 }
@@ -7,24 +7,46 @@ import (
 	"golang.org/x/sys/windows/registry"
 )
 
-func listEngines() (map[string]string, error) {
-	k, err := registry.OpenKey(registry.CURRENT_USER, `SOFTWARE\Epic Games\Unreal Engine\Builds`, registry.QUERY_VALUE)
+const (
+	buildsKey        = `SOFTWARE\Epic Games\Unreal Engine\Builds`
+	installationsKey = `SOFTWARE\EpicGames\Unreal Engine`
+)
+
+// listEngines returns the source builds registered by UnrealVersionSelector and the engines installed by the launcher,
+// and the registry keys it searched.
+func listEngines() (map[string]string, []string, error) {
+	engines := make(map[string]string)
+	searched := []string{`HKCU\` + buildsKey, `HKLM\` + installationsKey}
+
+	if err := listBuilds(engines); err != nil {
+		return nil, nil, err
+	}
+	if err := listInstallations(engines); err != nil {
+		return nil, nil, err
+	}
+
+	return engines, searched, nil
+}
+
+func listBuilds(engines map[string]string) error {
+	k, err := registry.OpenKey(registry.CURRENT_USER, buildsKey, registry.QUERY_VALUE)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to open registry key")
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to open registry key")
 	}
 	defer k.Close()
 
-	engines := make(map[string]string)
-
 	values, err := k.ReadValueNames(0)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read registry values")
+		return errors.Wrap(err, "failed to read registry values")
 	}
 
 	for _, value := range values {
 		path, _, err := k.GetStringValue(value)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to get registry value")
+			return errors.Wrap(err, "failed to get registry value")
 		}
 		engineVersion := value
 		if strings.HasPrefix(engineVersion, "UE_") {
@@ -33,5 +55,81 @@ func listEngines() (map[string]string, error) {
 		engines[engineVersion] = path
 	}
 
-	return engines, nil
+	return nil
+}
+
+func listInstallations(engines map[string]string) error {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, installationsKey, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to open registry key")
+	}
+	defer k.Close()
+
+	versions, err := k.ReadSubKeyNames(0)
+	if err != nil {
+		return errors.Wrap(err, "failed to read registry keys")
+	}
+
+	for _, version := range versions {
+		versionKey, err := registry.OpenKey(k, version, registry.QUERY_VALUE)
+		if err != nil {
+			return errors.Wrap(err, "failed to open registry key")
+		}
+		path, _, err := versionKey.GetStringValue("InstalledDirectory")
+		versionKey.Close()
+		if err != nil {
+			if errors.Is(err, registry.ErrNotExist) {
+				continue
+			}
+			return errors.Wrap(err, "failed to get registry value")
+		}
+		engines[version] = path
+	}
+
+	return nil
+}
+
+// registerEngine registers a source build the same way UnrealVersionSelector does.
+func registerEngine(identifier string, engineRoot string) error {
+	k, _, err := registry.CreateKey(registry.CURRENT_USER, buildsKey, registry.SET_VALUE)
+	if err != nil {
+		return errors.Wrap(err, "failed to open registry key")
+	}
+	defer k.Close()
+
+	if err := k.SetStringValue(identifier, engineRoot); err != nil {
+		return errors.Wrap(err, "failed to set registry value")
+	}
+	return nil
+}
+
+// unregisterEngine removes a source build. Engines installed by the launcher can only be removed with the launcher.
+func unregisterEngine(identifier string) (bool, error) {
+	k, err := registry.OpenKey(registry.CURRENT_USER, buildsKey, registry.QUERY_VALUE|registry.SET_VALUE)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to open registry key")
+	}
+	defer k.Close()
+
+	values, err := k.ReadValueNames(0)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read registry values")
+	}
+
+	removed := false
+	for _, value := range values {
+		if strings.EqualFold(value, identifier) || strings.EqualFold(value, "UE_"+identifier) {
+			if err := k.DeleteValue(value); err != nil {
+				return false, errors.Wrap(err, "failed to delete registry value")
+			}
+			removed = true
+		}
+	}
+	return removed, nil
 }
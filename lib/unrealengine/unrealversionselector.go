@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/html/charset"
@@ -14,41 +15,82 @@ import (
 	"golang.org/x/text/transform"
 )
 
-type UProject struct {
-	EngineAssociation string `json:"EngineAssociation"`
-}
-
 func getEngineAssociationFromProject(projectPath string) (string, error) {
-	projectData, err := os.ReadFile(projectPath)
+	uproject, err := ReadUProject(projectPath)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to read project file")
-	}
-
-	var uproject UProject
-	if err := json.Unmarshal(projectData, &uproject); err != nil {
-		return "", errors.Wrap(err, "failed to unmarshal project file")
+		return "", err
 	}
 
 	return uproject.EngineAssociation, nil
 }
 
+// GetEngineRootFromProject resolves the engine the project is associated with, the same way UnrealVersionSelector does:
+// registered engines by identifier, paths relative to the project, and, for empty or unregistered associations,
+// the engine tree the project is in.
 func GetEngineRootFromProject(projectPath string) (string, error) {
 	engineAssociation, err := getEngineAssociationFromProject(projectPath)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get engine association")
 	}
 
-	engines, err := listEngines()
+	projectDir, err := filepath.Abs(filepath.Dir(projectPath))
 	if err != nil {
-		return "", errors.Wrap(err, "failed to list engines")
+		return "", errors.Wrap(err, "failed to get project directory")
+	}
+
+	var searched []string
+
+	if engineAssociation != "" {
+		engines, registries, err := listEngines()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list engines")
+		}
+		searched = append(searched, registries...)
+
+		for identifier, engineRoot := range engines {
+			if strings.EqualFold(identifier, engineAssociation) {
+				return engineRoot, nil
+			}
+		}
+
+		if isPathAssociation(engineAssociation) {
+			engineRoot := engineAssociation
+			if !filepath.IsAbs(engineRoot) {
+				engineRoot = filepath.Join(projectDir, engineRoot)
+			}
+			searched = append(searched, engineRoot)
+			if isEngineRoot(engineRoot) {
+				return filepath.Clean(engineRoot), nil
+			}
+		}
 	}
 
-	engineRoot, ok := engines[engineAssociation]
-	if !ok {
-		return "", errors.New("failed to find engine")
+	// Projects inside the engine tree (such as source builds) use the engine they are in
+	for dir := projectDir; ; dir = filepath.Dir(dir) {
+		searched = append(searched, dir)
+		if isEngineRoot(dir) {
+			return dir, nil
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
 	}
 
-	return engineRoot, nil
+	return "", errors.Errorf("failed to find engine %q, searched:\n  %s", engineAssociation, strings.Join(searched, "\n  "))
+}
+
+// isPathAssociation returns whether the association is a path to the engine, rather than a version or a build identifier.
+func isPathAssociation(engineAssociation string) bool {
+	return filepath.IsAbs(engineAssociation) || strings.ContainsAny(engineAssociation, `/\`) || strings.HasPrefix(engineAssociation, ".")
+}
+
+func isEngineRoot(dir string) bool {
+	for _, versionFile := range []string{buildVersionFile, versionHeaderFile} {
+		if info, err := os.Stat(filepath.Join(dir, versionFile)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
 }
 
 type EngineBuildFile struct {
@@ -63,25 +105,37 @@ type EngineBuildFile struct {
 }
 
 func GetEngineVersionData(enginePath string) (EngineBuildFile, error) {
-	buildVersionFilePath := filepath.Join(enginePath, "Engine", "Build", "Build.version")
+	buildVersionFilePath := filepath.Join(enginePath, buildVersionFile)
 
-	buildVersionData, err := os.ReadFile(buildVersionFilePath)
-	if err != nil {
+	var buildVersion EngineBuildFile
+	if err := decodeJSONFile(buildVersionFilePath, &buildVersion); err != nil {
 		return EngineBuildFile{}, errors.Wrap(err, "failed to read build version file")
 	}
-	encoding, _, _, err := determineEncodingFromReader(bytes.NewReader(buildVersionData), len(buildVersionData))
+
+	return buildVersion, nil
+}
+
+// decodeJSONFile decodes a JSON file written by UE tools, which may be UTF-16 or have a BOM.
+func decodeJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return EngineBuildFile{}, errors.Wrap(err, "failed to determine encoding")
+		return errors.Wrap(err, "failed to read file")
+	}
+	encoding, _, _, err := determineEncodingFromReader(bytes.NewReader(data), len(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to determine encoding")
 	}
 
-	reader := transform.NewReader(bytes.NewReader(buildVersionData), encoding.NewDecoder())
+	decoded, err := io.ReadAll(transform.NewReader(bytes.NewReader(data), encoding.NewDecoder()))
+	if err != nil {
+		return errors.Wrap(err, "failed to decode file")
+	}
 
-	var buildVersion EngineBuildFile
-	if err := json.NewDecoder(reader).Decode(&buildVersion); err != nil {
-		return EngineBuildFile{}, errors.Wrap(err, "failed to unmarshal build version file")
+	if err := json.Unmarshal(bytes.TrimPrefix(decoded, []byte("\ufeff")), v); err != nil {
+		return errors.Wrap(err, "failed to unmarshal file")
 	}
 
-	return buildVersion, nil
+	return nil
 }
 
 func determineEncodingFromReader(r io.Reader, size int) (e encoding.Encoding, name string, certain bool, err error) {
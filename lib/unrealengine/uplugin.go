@@ -0,0 +1,21 @@
+package unrealengine
+
+import (
+	"github.com/pkg/errors"
+)
+
+type UPlugin struct {
+	FileVersion   int    `json:"FileVersion"`
+	Version       int    `json:"Version"`
+	VersionName   string `json:"VersionName"`
+	FriendlyName  string `json:"FriendlyName"`
+	EngineVersion string `json:"EngineVersion"`
+}
+
+func ReadUPlugin(upluginPath string) (UPlugin, error) {
+	var uplugin UPlugin
+	if err := decodeJSONFile(upluginPath, &uplugin); err != nil {
+		return UPlugin{}, errors.Wrap(err, "failed to read plugin file")
+	}
+	return uplugin, nil
+}
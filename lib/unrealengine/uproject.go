@@ -0,0 +1,242 @@
+package unrealengine
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type UProjectModule struct {
+	Name                   string   `json:"Name"`
+	Type                   string   `json:"Type"`
+	LoadingPhase           string   `json:"LoadingPhase"`
+	AdditionalDependencies []string `json:"AdditionalDependencies"`
+}
+
+type UProjectPlugin struct {
+	Name                     string   `json:"Name"`
+	Enabled                  bool     `json:"Enabled"`
+	Optional                 bool     `json:"Optional"`
+	MarketplaceURL           string   `json:"MarketplaceURL"`
+	SupportedTargetPlatforms []string `json:"SupportedTargetPlatforms"`
+	PlatformAllowList        []string `json:"PlatformAllowList"`
+	PlatformDenyList         []string `json:"PlatformDenyList"`
+	TargetAllowList          []string `json:"TargetAllowList"`
+	TargetDenyList           []string `json:"TargetDenyList"`
+	// WhitelistPlatforms and BlacklistPlatforms are the UE4 names of PlatformAllowList and PlatformDenyList.
+	WhitelistPlatforms []string `json:"WhitelistPlatforms"`
+	BlacklistPlatforms []string `json:"BlacklistPlatforms"`
+}
+
+type UProject struct {
+	FileVersion                   int              `json:"FileVersion"`
+	EngineAssociation             string           `json:"EngineAssociation"`
+	Category                      string           `json:"Category"`
+	Description                   string           `json:"Description"`
+	Modules                       []UProjectModule `json:"Modules"`
+	Plugins                       []UProjectPlugin `json:"Plugins"`
+	TargetPlatforms               []string         `json:"TargetPlatforms"`
+	AdditionalRootDirectories     []string         `json:"AdditionalRootDirectories"`
+	AdditionalPluginDirectories   []string         `json:"AdditionalPluginDirectories"`
+	IsEnterpriseProject           bool             `json:"IsEnterpriseProject"`
+	DisableEnginePluginsByDefault bool             `json:"DisableEnginePluginsByDefault"`
+}
+
+func ReadUProject(projectPath string) (UProject, error) {
+	var uproject UProject
+	if err := decodeJSONFile(projectPath, &uproject); err != nil {
+		return UProject{}, errors.Wrap(err, "failed to read project file")
+	}
+	return uproject, nil
+}
+
+// platformAllowListKey returns the name of the plugin platform allow list in the project files of the engine version.
+func platformAllowListKey(engineVersion EngineVersion) string {
+	if engineVersion.Major >= 5 {
+		return "PlatformAllowList"
+	}
+	return "WhitelistPlatforms"
+}
+
+// ProjectPluginChange is how EnableProjectPlugins changed the entry of a plugin in the project file, so that it can be reverted.
+type ProjectPluginChange struct {
+	Name string `json:"name"`
+	// Added is set if the project had no entry for the plugin, so that reverting removes the entry.
+	Added bool `json:"added,omitempty"`
+	// EnabledChanged is set if the existing entry was not enabled. PreviousEnabled is the value it had, or nil if the entry had none.
+	EnabledChanged  bool        `json:"enabledChanged,omitempty"`
+	PreviousEnabled interface{} `json:"previousEnabled,omitempty"`
+	// AllowListKey is set if the platform allow list of the entry was replaced.
+	// PreviousAllowList is the list it replaced, or nil if the entry had none.
+	AllowListKey      string      `json:"allowListKey,omitempty"`
+	PreviousAllowList interface{} `json:"previousAllowList,omitempty"`
+}
+
+// findProjectPlugin returns the index of the entry of a plugin in the Plugins list, or -1 if there is none.
+func findProjectPlugin(plugins []interface{}, name string) int {
+	for i, pluginValue := range plugins {
+		if plugin, ok := pluginValue.(jsonObject); ok {
+			pluginName, _ := plugin.get("Name")
+			if pluginNameString, ok := pluginName.(string); ok && strings.EqualFold(pluginNameString, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func projectPlugins(doc *jsonDocument) ([]interface{}, error) {
+	pluginsValue, ok := doc.Root.get("Plugins")
+	if !ok {
+		return nil, nil
+	}
+	plugins, ok := pluginsValue.([]interface{})
+	if !ok {
+		return nil, errors.New("project Plugins is not a list")
+	}
+	return plugins, nil
+}
+
+// EnableProjectPlugins returns the content of the project file with the given plugins enabled, and how the entries
+// of the plugins were changed, if at all. Existing entries are updated in place, keeping their other settings.
+// If platformAllowList is not empty, the plugins are only enabled for those platforms.
+func EnableProjectPlugins(projectPath string, names []string, platformAllowList []string, engineVersion EngineVersion) ([]byte, []ProjectPluginChange, error) {
+	doc, err := readJSONDocument(projectPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read project file")
+	}
+
+	plugins, err := projectPlugins(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var allowList []interface{}
+	for _, platform := range platformAllowList {
+		allowList = append(allowList, platform)
+	}
+
+	var changes []ProjectPluginChange
+	for _, name := range names {
+		change := ProjectPluginChange{Name: name}
+		changed := false
+
+		index := findProjectPlugin(plugins, name)
+		if index < 0 {
+			plugins = append(plugins, jsonObject{{Key: "Name", Value: name}})
+			index = len(plugins) - 1
+			change.Added = true
+			changed = true
+		}
+
+		plugin := plugins[index].(jsonObject)
+		if enabled, _ := plugin.get("Enabled"); enabled != true {
+			plugin.set("Enabled", true)
+			if !change.Added {
+				change.EnabledChanged = true
+				change.PreviousEnabled = enabled
+			}
+			changed = true
+		}
+		if len(allowList) > 0 {
+			key := platformAllowListKey(engineVersion)
+			if current, _ := plugin.get(key); !sameJSONStrings(current, allowList) {
+				plugin.set(key, allowList)
+				change.AllowListKey = key
+				change.PreviousAllowList = current
+				changed = true
+			}
+		}
+		plugins[index] = plugin
+
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+
+	doc.Root.set("Plugins", plugins)
+	content, err := doc.encode()
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, changes, nil
+}
+
+func sameJSONStrings(value interface{}, expected []interface{}) bool {
+	values, ok := value.([]interface{})
+	if !ok || len(values) != len(expected) {
+		return false
+	}
+	for i := range values {
+		if values[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RevertProjectPlugins returns the content of the project file with the changes of EnableProjectPlugins reverted, last first,
+// and whether it differs from the current content. Entries the changes added are removed, and the others get back
+// their previous Enabled and platform allow list, or lose them if they had none.
+func RevertProjectPlugins(projectPath string, changes []ProjectPluginChange) ([]byte, bool, error) {
+	doc, err := readJSONDocument(projectPath)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to read project file")
+	}
+
+	plugins, err := projectPlugins(doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		index := findProjectPlugin(plugins, change.Name)
+		if index < 0 {
+			continue
+		}
+		changed = true
+
+		if change.Added {
+			plugins = append(plugins[:index], plugins[index+1:]...)
+			continue
+		}
+
+		plugin := plugins[index].(jsonObject)
+		if change.EnabledChanged {
+			if change.PreviousEnabled == nil {
+				plugin.remove("Enabled")
+			} else {
+				plugin.set("Enabled", change.PreviousEnabled)
+			}
+		}
+		if change.AllowListKey != "" {
+			if change.PreviousAllowList == nil {
+				plugin.remove(change.AllowListKey)
+			} else {
+				plugin.set(change.AllowListKey, change.PreviousAllowList)
+			}
+		}
+		plugins[index] = plugin
+	}
+
+	if !changed {
+		return nil, false, nil
+	}
+
+	if len(plugins) == 0 {
+		doc.Root.remove("Plugins")
+	} else {
+		doc.Root.set("Plugins", plugins)
+	}
+	content, err := doc.encode()
+	if err != nil {
+		return nil, false, err
+	}
+	return content, true, nil
+}
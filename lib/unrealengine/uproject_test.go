@@ -0,0 +1,232 @@
+package unrealengine
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+// testUProject is a project file the way the UE editor writes it: tab indented, without a trailing line ending.
+const testUProject = `{
+	"FileVersion": 3,
+	"EngineAssociation": "5.3",
+	"Category": "",
+	"Description": "",
+	"Modules": [
+		{
+			"Name": "Game",
+			"Type": "Runtime",
+			"LoadingPhase": "Default"
+		}
+	],
+	"Plugins": [
+		{
+			"Name": "ModelingToolsEditorMode",
+			"Enabled": true,
+			"TargetAllowList": [
+				"Editor"
+			]
+		}
+	]
+}`
+
+func writeUProject(t *testing.T, content []byte) string {
+	t.Helper()
+	projectPath := filepath.Join(t.TempDir(), "Game.uproject")
+	if err := os.WriteFile(projectPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectPath
+}
+
+func utf16LE(t *testing.T, content string) []byte {
+	t.Helper()
+	encoded, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().Bytes([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func TestJSONDocumentRoundTrip(t *testing.T) {
+	crlf := strings.ReplaceAll(testUProject, "\n", "\r\n")
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{"tabs", []byte(testUProject)},
+		{"trailing line ending", []byte(testUProject + "\n")},
+		{"CRLF", []byte(crlf + "\r\n")},
+		{"spaces", []byte(strings.ReplaceAll(testUProject, "\t", "    "))},
+		{"BOM", append(append([]byte{}, utf8BOM...), crlf...)},
+		{"UTF-16", utf16LE(t, strings.Replace(crlf, `"Description": ""`, `"Description": "Jeu de démonstration"`, 1))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := readJSONDocument(writeUProject(t, test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := doc.encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(content, test.content) {
+				t.Errorf("encoded as:\n%q\nwant:\n%q", content, test.content)
+			}
+		})
+	}
+}
+
+func TestJSONDocumentUTF16(t *testing.T) {
+	doc, err := readJSONDocument(writeUProject(t, utf16LE(t, strings.Replace(testUProject, `"Description": ""`, `"Description": "Jeu de démonstration"`, 1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description, _ := doc.Root.get("Description"); description != "Jeu de démonstration" {
+		t.Errorf("Description = %q", description)
+	}
+}
+
+// withPlugin returns testUProject with another entry at the end of its Plugins.
+func withPlugin(entry string) string {
+	if entry == "" {
+		return testUProject
+	}
+	return strings.TrimSuffix(testUProject, "\n\t]\n}") + "," + entry + "\n\t]\n}"
+}
+
+func decodeUTF16(content []byte) string {
+	decoded, _ := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewDecoder().Bytes(content)
+	return string(decoded)
+}
+
+func TestEnableProjectPlugins(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		want  string
+	}{
+		{
+			name: "no entry",
+			want: `
+		{
+			"Name": "Wwise",
+			"Enabled": true,
+			"PlatformAllowList": [
+				"Win64"
+			]
+		}`,
+		},
+		{
+			name: "disabled entry",
+			entry: `
+		{
+			"Name": "Wwise",
+			"Enabled": false,
+			"MarketplaceURL": "com.epicgames.launcher://ue/marketplace"
+		}`,
+			want: `
+		{
+			"Name": "Wwise",
+			"Enabled": true,
+			"MarketplaceURL": "com.epicgames.launcher://ue/marketplace",
+			"PlatformAllowList": [
+				"Win64"
+			]
+		}`,
+		},
+		{
+			name: "entry without Enabled",
+			entry: `
+		{
+			"Name": "wwise",
+			"PlatformAllowList": [
+				"Win64",
+				"Linux"
+			]
+		}`,
+			want: `
+		{
+			"Name": "wwise",
+			"PlatformAllowList": [
+				"Win64"
+			],
+			"Enabled": true
+		}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := utf16LE(t, withPlugin(test.entry))
+			projectPath := writeUProject(t, original)
+
+			content, changes, err := EnableProjectPlugins(projectPath, []string{"Wwise"}, []string{"Win64"}, EngineVersion{Major: 5, Minor: 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changes) != 1 {
+				t.Fatalf("%d changes, want 1", len(changes))
+			}
+			if want := withPlugin(test.want); decodeUTF16(content) != want || !bytes.HasPrefix(content, utf16LEBOM) {
+				t.Errorf("enabled as:\n%s\nwant:\n%s", decodeUTF16(content), want)
+			}
+			if err := os.WriteFile(projectPath, content, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Enabling again changes nothing
+			if _, again, err := EnableProjectPlugins(projectPath, []string{"Wwise"}, []string{"Win64"}, EngineVersion{Major: 5, Minor: 3}); err != nil || len(again) != 0 {
+				t.Errorf("enabling again made changes %v, %v", again, err)
+			}
+
+			// Reverting gives back the original file
+			reverted, changed, err := RevertProjectPlugins(projectPath, changes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !changed {
+				t.Fatal("reverting made no changes")
+			}
+			if !bytes.Equal(reverted, original) {
+				t.Errorf("reverted as:\n%s\nwant:\n%s", decodeUTF16(reverted), decodeUTF16(original))
+			}
+		})
+	}
+}
+
+func TestRevertProjectPluginsRemovesAddedPluginsList(t *testing.T) {
+	original := strings.Replace(testUProject, `,
+	"Plugins": [
+		{
+			"Name": "ModelingToolsEditorMode",
+			"Enabled": true,
+			"TargetAllowList": [
+				"Editor"
+			]
+		}
+	]`, "", 1)
+	projectPath := writeUProject(t, []byte(original))
+
+	content, changes, err := EnableProjectPlugins(projectPath, []string{"Wwise", "WwiseNiagara"}, nil, EngineVersion{Major: 4, Minor: 27})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(projectPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reverted, _, err := RevertProjectPlugins(projectPath, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(reverted) != original {
+		t.Errorf("reverted as:\n%s\nwant:\n%s", reverted, original)
+	}
+}
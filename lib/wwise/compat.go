@@ -0,0 +1,174 @@
+package wwise
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/mircearoata/wwise-cli/lib/wwise/client"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+// UnrealCompatibility is the Unreal Engine versions an integration version supports.
+type UnrealCompatibility struct {
+	IntegrationVersion string
+	Version            product.Version
+	EngineVersions     []product.SupportedUnrealVersions
+	// Bundle is the release of the integration version, if it was listed by the Wwise API.
+	Bundle product.Bundle
+}
+
+func (c UnrealCompatibility) Supports(major int, minor int) bool {
+	for _, engineVersion := range c.EngineVersions {
+		if engineVersion.Major == major && engineVersion.Minor == minor {
+			return true
+		}
+	}
+	return false
+}
+
+func compareVersions(a product.Version, b product.Version) int {
+	for _, diff := range []int{a.Year - b.Year, a.Major - b.Major, a.Minor - b.Minor, a.Build - b.Build} {
+		if diff != 0 {
+			return diff
+		}
+	}
+	return 0
+}
+
+func formatEngineVersions(engineVersions []product.SupportedUnrealVersions) string {
+	formatted := make([]string, 0, len(engineVersions))
+	for _, engineVersion := range engineVersions {
+		formatted = append(formatted, fmt.Sprintf("%d.%d", engineVersion.Major, engineVersion.Minor))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// UnrealCompatibilityMatrix returns the engine versions supported by every integration version, newest first.
+func UnrealCompatibilityMatrix(wwiseClient *client.WwiseClient) ([]UnrealCompatibility, error) {
+	productInfo, err := product.NewWwiseProduct(wwiseClient, "unrealintegration").GetInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get unreal integration versions")
+	}
+
+	matrix := make([]UnrealCompatibility, 0, len(productInfo.Bundles))
+	for _, bundle := range productInfo.Bundles {
+		engineVersions := append([]product.SupportedUnrealVersions{}, bundle.ProductDependentData.SupportedUnrealVersions...)
+		sort.Slice(engineVersions, func(i, j int) bool {
+			if engineVersions[i].Major != engineVersions[j].Major {
+				return engineVersions[i].Major < engineVersions[j].Major
+			}
+			return engineVersions[i].Minor < engineVersions[j].Minor
+		})
+		matrix = append(matrix, UnrealCompatibility{
+			IntegrationVersion: strings.TrimPrefix(bundle.ID, "unrealintegration."),
+			Version:            bundle.Version,
+			EngineVersions:     engineVersions,
+			Bundle:             bundle,
+		})
+	}
+
+	sort.SliceStable(matrix, func(i, j int) bool {
+		return compareVersions(matrix[i].Version, matrix[j].Version) > 0
+	})
+
+	return matrix, nil
+}
+
+// checkUnrealCompatibility fails if the integration does not support the engine version, suggesting the nearest
+// older and newer integration versions that do. Integrations that do not list their supported versions are not checked.
+func checkUnrealCompatibility(versionInfo product.ProductVersionInfo, engineVersion unrealengine.EngineVersion, wwiseClient *client.WwiseClient) error {
+	integration := UnrealCompatibility{
+		IntegrationVersion: strings.TrimPrefix(versionInfo.ID, "unrealintegration."),
+		Version:            versionInfo.Version,
+		EngineVersions:     versionInfo.ProductDependentData.SupportedUnrealVersions,
+	}
+	if len(integration.EngineVersions) == 0 || integration.Supports(engineVersion.Major, engineVersion.Minor) {
+		return nil
+	}
+
+	message := fmt.Sprintf("integration %s does not support UE %d.%d, it supports UE %s",
+		integration.IntegrationVersion, engineVersion.Major, engineVersion.Minor, formatEngineVersions(integration.EngineVersions))
+
+	matrix, err := UnrealCompatibilityMatrix(wwiseClient)
+	if err != nil {
+		return errors.Wrap(err, message)
+	}
+
+	// The matrix is sorted newest first, so the nearest newer version is the last one before the integration
+	var older, newer *UnrealCompatibility
+	for i := range matrix {
+		if !matrix[i].Supports(engineVersion.Major, engineVersion.Minor) {
+			continue
+		}
+		if compareVersions(matrix[i].Version, integration.Version) > 0 {
+			newer = &matrix[i]
+		} else if older == nil {
+			older = &matrix[i]
+		}
+	}
+
+	var suggestions []string
+	for _, suggestion := range []*UnrealCompatibility{older, newer} {
+		if suggestion != nil {
+			suggestions = append(suggestions, suggestion.IntegrationVersion)
+		}
+	}
+	if len(suggestions) == 0 {
+		return errors.Errorf("%s, and no integration version supports UE %d.%d", message, engineVersion.Major, engineVersion.Minor)
+	}
+
+	return errors.Errorf("%s, the nearest versions supporting UE %d.%d are %s", message, engineVersion.Major, engineVersion.Minor, strings.Join(suggestions, ", "))
+}
+
+// ErrUnknownIntegrationVersion is the cause of the errors of versions that cannot be compared, like the VersionName
+// of integrations not installed by wwise-cli, which is not always a year.major.minor.build version.
+var ErrUnknownIntegrationVersion = errors.New("unknown integration version")
+
+// parseIntegrationVersion parses a year.major.minor.build version id.
+func parseIntegrationVersion(versionId string) (product.Version, error) {
+	parts := strings.Split(strings.TrimPrefix(versionId, "unrealintegration."), ".")
+	if len(parts) != 4 {
+		return product.Version{}, errors.Wrapf(ErrUnknownIntegrationVersion, "version %s is not year.major.minor.build", versionId)
+	}
+
+	numbers := make([]int, 4)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return product.Version{}, errors.Wrapf(ErrUnknownIntegrationVersion, "version %s is not year.major.minor.build", versionId)
+		}
+		numbers[i] = number
+	}
+
+	return product.Version{Year: numbers[0], Major: numbers[1], Minor: numbers[2], Build: numbers[3]}, nil
+}
+
+// NewerUnrealIntegrations returns the integration versions newer than the given one that support the engine version, newest first.
+// The cause of the error is ErrUnknownIntegrationVersion if the given version cannot be compared.
+func NewerUnrealIntegrations(integrationVersion string, engineVersion unrealengine.EngineVersion, wwiseClient *client.WwiseClient) ([]UnrealCompatibility, error) {
+	current, err := parseIntegrationVersion(integrationVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix, err := UnrealCompatibilityMatrix(wwiseClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return newerIntegrations(matrix, current, engineVersion), nil
+}
+
+func newerIntegrations(matrix []UnrealCompatibility, current product.Version, engineVersion unrealengine.EngineVersion) []UnrealCompatibility {
+	var newer []UnrealCompatibility
+	for _, integration := range matrix {
+		if compareVersions(integration.Version, current) > 0 && integration.Supports(engineVersion.Major, engineVersion.Minor) {
+			newer = append(newer, integration)
+		}
+	}
+	return newer
+}
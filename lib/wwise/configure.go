@@ -0,0 +1,247 @@
+package wwise
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+const akSettingsSection = "/Script/AkAudio.AkSettings"
+
+// UnrealSettings are the Wwise settings written to the Config ini files of a project.
+type UnrealSettings struct {
+	// WwiseProject is the .wproj of the Wwise project of the game.
+	WwiseProject string
+	// SoundBanksDir is where the Wwise project generates its sound banks. Empty means GeneratedSoundBanks next to the .wproj.
+	SoundBanksDir string
+	// Platforms are the UE platforms whose initialization settings are written.
+	Platforms []string
+	// IntegrationVersion is the version of the integration the settings are for, as the settings changed between versions.
+	IntegrationVersion product.Version
+}
+
+// contentRelativePath returns p relative to the Content directory of the project, which is what the Wwise
+// integration resolves the relative paths of its settings against.
+func contentRelativePath(projectDir string, p string) (string, error) {
+	absPath, err := filepath.Abs(p)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get absolute path")
+	}
+	absContentDir, err := filepath.Abs(filepath.Join(projectDir, "Content"))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get absolute content directory")
+	}
+	relPath, err := filepath.Rel(absContentDir, absPath)
+	if err != nil {
+		// Different volumes, keep the absolute path
+		return filepath.ToSlash(absPath), nil
+	}
+	return filepath.ToSlash(relPath), nil
+}
+
+// initializationSettingsSection returns the section of the Wwise initialization settings of a UE platform.
+func initializationSettingsSection(platform string) string {
+	if platform == "Win64" {
+		platform = "Windows"
+	}
+	return fmt.Sprintf("/Script/AkAudio.Ak%sInitializationSettings", platform)
+}
+
+func unrealConfigSettings(uprojectFilePath string, settings UnrealSettings) ([]unrealengine.ConfigSetting, error) {
+	if filepath.Ext(settings.WwiseProject) != ".wproj" {
+		return nil, errors.New("invalid Wwise project path: " + settings.WwiseProject)
+	}
+	if _, err := os.Stat(settings.WwiseProject); err != nil {
+		return nil, errors.Wrap(err, "invalid Wwise project")
+	}
+
+	projectDir := filepath.Dir(uprojectFilePath)
+	wwiseProject, err := contentRelativePath(projectDir, settings.WwiseProject)
+	if err != nil {
+		return nil, err
+	}
+
+	soundBanksDir := settings.SoundBanksDir
+	if soundBanksDir == "" {
+		soundBanksDir = filepath.Join(filepath.Dir(settings.WwiseProject), "GeneratedSoundBanks")
+	}
+	soundBanksDir, err = contentRelativePath(projectDir, soundBanksDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2022.1 replaced GeneratedSoundBanksFolder with RootOutputPath
+	soundBanksKey := "GeneratedSoundBanksFolder"
+	if version := settings.IntegrationVersion; version.Year > 2022 || (version.Year == 2022 && version.Major >= 1) {
+		soundBanksKey = "RootOutputPath"
+	}
+
+	configSettings := []unrealengine.ConfigSetting{
+		{File: "DefaultGame.ini", Section: akSettingsSection, Key: "WwiseProjectPath", Value: fmt.Sprintf("(FilePath=%q)", wwiseProject)},
+		{File: "DefaultGame.ini", Section: akSettingsSection, Key: soundBanksKey, Value: fmt.Sprintf("(Path=%q)", soundBanksDir)},
+	}
+
+	// Name the game in the Wwise authoring tool's remote connections after the project
+	projectName := filepath.Base(uprojectFilePath)
+	projectName = projectName[:len(projectName)-len(filepath.Ext(projectName))]
+	for _, platform := range settings.Platforms {
+		configSettings = append(configSettings, unrealengine.ConfigSetting{
+			File:    "DefaultGame.ini",
+			Section: initializationSettingsSection(platform),
+			Key:     "CommunicationSettings",
+			Value:   fmt.Sprintf("(NetworkName=%q)", projectName),
+		})
+	}
+
+	return configSettings, nil
+}
+
+// unrealSettingsEdits returns the edits of the project's config files that apply the settings.
+func unrealSettingsEdits(uprojectFilePath string, settings UnrealSettings) ([]install.FileEdit, error) {
+	configSettings, err := unrealConfigSettings(uprojectFilePath, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir := filepath.Dir(uprojectFilePath)
+	changed, changes, err := unrealengine.SetConfigSettings(projectDir, configSettings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to configure Wwise settings")
+	}
+
+	fileChanges := make(map[string][]unrealengine.ConfigChange)
+	for _, change := range changes {
+		configPath := filepath.Join(projectDir, "Config", filepath.FromSlash(change.File))
+		fileChanges[configPath] = append(fileChanges[configPath], change)
+	}
+
+	paths := make([]string, 0, len(changed))
+	for configPath := range changed {
+		paths = append(paths, configPath)
+	}
+	sort.Strings(paths)
+
+	var edits []install.FileEdit
+	for _, configPath := range paths {
+		edit, err := install.NewFileEdit(configPath, changed[configPath], "configure Wwise settings")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to plan config file edit")
+		}
+		edit.Changes, err = json.Marshal(unrealEditChanges{Config: fileChanges[configPath]})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal config changes")
+		}
+		edits = append(edits, edit)
+	}
+	return edits, nil
+}
+
+// unrealSettingsDrift returns the config files of the project that no longer have the settings recorded in the install manifest,
+// such as a setting reverted by hand.
+func unrealSettingsDrift(target UnrealTarget, installed *install.Manifest) ([]string, error) {
+	_, configChanges, err := recordedUnrealChanges(installed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the last value set for a key is expected, array elements are all expected
+	var settings []unrealengine.ConfigSetting
+	last := make(map[unrealengine.ConfigSetting]int)
+	for _, change := range configChanges {
+		setting := change.ConfigSetting
+		if !strings.HasPrefix(setting.Key, "+") {
+			key := unrealengine.ConfigSetting{File: setting.File, Section: setting.Section, Key: setting.Key}
+			if i, ok := last[key]; ok {
+				settings[i] = setting
+				continue
+			}
+			last[key] = len(settings)
+		}
+		settings = append(settings, setting)
+	}
+
+	changed, _, err := unrealengine.SetConfigSettings(filepath.Dir(target.Project), settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check Wwise settings")
+	}
+	paths := make([]string, 0, len(changed))
+	for configPath := range changed {
+		paths = append(paths, configPath)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// installedIntegrationVersion returns the year and major version of the integration installed to the project.
+// Integrations that were not installed by wwise-cli may only have a year and major version.
+func installedIntegrationVersion(target UnrealTarget) (product.Version, error) {
+	installed, err := installedUnreal(target.PluginsDir())
+	if err != nil {
+		return product.Version{}, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if installed == nil {
+		return product.Version{}, errors.New("failed to find a Wwise integration in " + target.PluginsDir() + ", integrate Wwise first")
+	}
+
+	parts := strings.Split(strings.TrimPrefix(installed.IntegrationVersion, "unrealintegration."), ".")
+	if len(parts) >= 2 {
+		year, yearErr := strconv.Atoi(parts[0])
+		major, majorErr := strconv.Atoi(parts[1])
+		if yearErr == nil && majorErr == nil {
+			return product.Version{Year: year, Major: major}, nil
+		}
+	}
+	return product.Version{}, errors.Errorf("invalid integration version %s, expected year.major", installed.IntegrationVersion)
+}
+
+// ConfigureWwiseUnreal writes the Wwise settings for the integration installed to the project to its config files.
+// It returns the files that were changed, none if the settings are already set. The changes are applied like an
+// installation, so that they can be rolled back, and recorded in the install manifest if the integration was
+// installed by wwise-cli, so that uninstalling reverts them.
+func ConfigureWwiseUnreal(integrationTarget IntegrationTarget, settings UnrealSettings) ([]string, error) {
+	target, err := asUnrealTarget(integrationTarget)
+	if err != nil {
+		return nil, err
+	}
+	if target.IsEngine() {
+		return nil, errors.New("the Wwise project settings can only be configured for projects")
+	}
+
+	edits, err := configureEdits(target, settings)
+	if err != nil {
+		return nil, err
+	}
+	if len(edits) == 0 {
+		return nil, nil
+	}
+
+	if err := install.ApplyEdits(target.PluginsDir(), edits); err != nil {
+		return nil, errors.Wrap(err, "failed to write config files")
+	}
+
+	var changedFiles []string
+	for _, edit := range edits {
+		changedFiles = append(changedFiles, edit.Path)
+	}
+	return changedFiles, nil
+}
+
+// configureEdits returns the edits of the config files of the project target that apply the settings,
+// for the integration installed to it.
+func configureEdits(target UnrealTarget, settings UnrealSettings) ([]install.FileEdit, error) {
+	var err error
+	settings.IntegrationVersion, err = installedIntegrationVersion(target)
+	if err != nil {
+		return nil, err
+	}
+	return unrealSettingsEdits(target.Project, settings)
+}
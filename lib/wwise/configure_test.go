@@ -0,0 +1,169 @@
+package wwise
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/spf13/viper"
+)
+
+// integratedProject creates a project with an integration installed by wwise-cli, and returns its .uproject.
+func integratedProject(t *testing.T, integrationVersion string) string {
+	t.Helper()
+	viper.Set("cache-dir", t.TempDir())
+
+	projectDir := t.TempDir()
+	uprojectFilePath := filepath.Join(projectDir, "Game.uproject")
+	if err := os.WriteFile(uprojectFilePath, []byte("{\n\t\"FileVersion\": 3\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := install.Manifest{IntegrationVersion: integrationVersion, Files: map[string]string{}}
+	if err := manifest.Save(filepath.Join(projectDir, "Plugins")); err != nil {
+		t.Fatal(err)
+	}
+	wwiseProject := filepath.Join(projectDir, "Game_WwiseProject", "Game_WwiseProject.wproj")
+	if err := os.MkdirAll(filepath.Dir(wwiseProject), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(wwiseProject, []byte("<WwiseDocument/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return uprojectFilePath
+}
+
+func TestConfigureWwiseUnreal(t *testing.T) {
+	uprojectFilePath := integratedProject(t, "2022.1.5.2714")
+	projectDir := filepath.Dir(uprojectFilePath)
+	settings := UnrealSettings{WwiseProject: filepath.Join(projectDir, "Game_WwiseProject", "Game_WwiseProject.wproj")}
+
+	target, err := NewProjectTarget(uprojectFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changedFiles, err := ConfigureWwiseUnreal(target, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaultGame := filepath.Join(projectDir, "Config", "DefaultGame.ini")
+	if len(changedFiles) != 1 || changedFiles[0] != defaultGame {
+		t.Fatalf("changed files %v, want %s", changedFiles, defaultGame)
+	}
+
+	content, err := os.ReadFile(defaultGame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"[/Script/AkAudio.AkSettings]",
+		`WwiseProjectPath=(FilePath="../Game_WwiseProject/Game_WwiseProject.wproj")`,
+		`RootOutputPath=(Path="../Game_WwiseProject/GeneratedSoundBanks")`,
+	} {
+		if !strings.Contains(string(content), line) {
+			t.Errorf("DefaultGame.ini misses %s:\n%s", line, content)
+		}
+	}
+
+	pluginsDir := filepath.Join(projectDir, "Plugins")
+	manifest, err := install.ReadManifest(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Edits) != 1 || manifest.Edits[0].Path != defaultGame {
+		t.Fatalf("recorded edits %+v, want one of %s", manifest.Edits, defaultGame)
+	}
+
+	// Configuring again changes nothing, and records nothing more
+	changedFiles, err = ConfigureWwiseUnreal(target, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changedFiles) != 0 {
+		t.Errorf("changed files %v on the second run, want none", changedFiles)
+	}
+	manifest, err = install.ReadManifest(pluginsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Edits) != 1 {
+		t.Errorf("%d recorded edits after the second run, want 1", len(manifest.Edits))
+	}
+
+	// The edit is applied like an installation, so it can be rolled back
+	if err := install.Rollback(pluginsDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(defaultGame); !os.IsNotExist(err) {
+		t.Errorf("DefaultGame.ini still exists after rolling back: %v", err)
+	}
+}
+
+func TestConfigureWwiseUnrealWithoutIntegration(t *testing.T) {
+	viper.Set("cache-dir", t.TempDir())
+	projectDir := t.TempDir()
+	uprojectFilePath := filepath.Join(projectDir, "Game.uproject")
+	if err := os.WriteFile(uprojectFilePath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := NewProjectTarget(uprojectFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ConfigureWwiseUnreal(target, UnrealSettings{WwiseProject: filepath.Join(projectDir, "Game.wproj")})
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(projectDir, "Plugins")) {
+		t.Errorf("got error %v, want one naming the plugins directory of the project", err)
+	}
+}
+
+func TestUnrealSettingsDrift(t *testing.T) {
+	uprojectFilePath := integratedProject(t, "2022.1.5.2714")
+	projectDir := filepath.Dir(uprojectFilePath)
+	target, err := NewProjectTarget(uprojectFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, "Game_WwiseProject.wproj"), []byte("<WwiseDocument/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, wwiseProject := range []string{"Game_WwiseProject.wproj", "Game_WwiseProject/Game_WwiseProject.wproj"} {
+		if _, err := ConfigureWwiseUnreal(target, UnrealSettings{WwiseProject: filepath.Join(projectDir, filepath.FromSlash(wwiseProject))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the settings that were set last are expected
+	installed, err := install.ReadManifest(target.PluginsDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	drift, err := unrealSettingsDrift(target, installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drift) != 0 {
+		t.Errorf("drift %v right after configuring, want none", drift)
+	}
+
+	defaultGame := filepath.Join(projectDir, "Config", "DefaultGame.ini")
+	content, err := os.ReadFile(defaultGame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reverted := strings.Replace(string(content), "../Game_WwiseProject/Game_WwiseProject.wproj", "../Other.wproj", 1)
+	if err := os.WriteFile(defaultGame, []byte(reverted), 0644); err != nil {
+		t.Fatal(err)
+	}
+	drift, err = unrealSettingsDrift(target, installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(drift) != 1 || drift[0] != defaultGame {
+		t.Errorf("drift %v after changing a setting by hand, want %s", drift, defaultGame)
+	}
+}
@@ -0,0 +1,141 @@
+package wwise
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// knownDeploymentPlatforms is used for engine versions that neither the overrides nor the integration manifest map.
+var knownDeploymentPlatforms = map[string]string{
+	"4.26": "UE426",
+	"4.27": "UE427",
+	"5.0":  "UE50",
+	"5.1":  "UE51",
+	"5.2":  "UE52",
+	"5.3":  "UE53",
+	"5.4":  "UE54",
+	"5.5":  "UE55",
+}
+
+// DefaultDeploymentPlatformsFile returns the default path of the file overriding the DeploymentPlatforms value of engine versions.
+func DefaultDeploymentPlatformsFile() string {
+	userConfig, err := os.UserConfigDir()
+	if err != nil {
+		userConfig = "."
+	}
+	return filepath.Join(userConfig, "wwise-cli", "deployment-platforms.yaml")
+}
+
+// loadDeploymentPlatformOverrides reads the overrides file set by the deployment-platforms option, mapping major.minor
+// engine versions to DeploymentPlatforms values. A missing file has no overrides.
+func loadDeploymentPlatformOverrides() (map[string]string, error) {
+	path := viper.GetString("deployment-platforms")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read deployment platforms file")
+	}
+
+	var overrides map[string]string
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse deployment platforms file %s", path)
+	}
+	return overrides, nil
+}
+
+// deploymentPlatformVersions returns the engine versions, of the given ones, that the DeploymentPlatforms value can mean.
+// Values with a separator (UE5_10) are exact, values without one (UE510) can mean any split of their digits.
+func deploymentPlatformVersions(value string, engineVersions []product.SupportedUnrealVersions) []product.SupportedUnrealVersions {
+	digits := value
+	if len(digits) >= 2 && strings.EqualFold(digits[:2], "UE") {
+		digits = digits[2:]
+	}
+
+	var matches []product.SupportedUnrealVersions
+	for _, engineVersion := range engineVersions {
+		major, minor := strconv.Itoa(engineVersion.Major), strconv.Itoa(engineVersion.Minor)
+		matched := false
+		for _, separator := range []string{"", ".", "_", "-"} {
+			if digits == major+separator+minor {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, engineVersion)
+		}
+	}
+	return matches
+}
+
+// UnrealDeploymentPlatform returns the DeploymentPlatforms group value of the integration packages for the engine version.
+// The value is taken from the overrides file, then from the values in the integration manifest, if versionInfo is not nil,
+// then from the built-in table.
+func UnrealDeploymentPlatform(engineVersion unrealengine.EngineVersion, versionInfo *product.ProductVersionInfo) (string, error) {
+	key := fmt.Sprintf("%d.%d", engineVersion.Major, engineVersion.Minor)
+
+	overrides, err := loadDeploymentPlatformOverrides()
+	if err != nil {
+		return "", err
+	}
+	if value, ok := overrides[key]; ok {
+		return value, nil
+	}
+
+	if versionInfo != nil {
+		// A value is only unambiguous if no other engine the integration supports could produce it
+		engine := product.SupportedUnrealVersions{Major: engineVersion.Major, Minor: engineVersion.Minor}
+		engineVersions := []product.SupportedUnrealVersions{engine}
+		for _, supported := range versionInfo.ProductDependentData.SupportedUnrealVersions {
+			if supported != engine {
+				engineVersions = append(engineVersions, supported)
+			}
+		}
+
+		var candidates []string
+		ambiguous := false
+		for _, value := range versionInfo.GroupValues("DeploymentPlatforms") {
+			matches := deploymentPlatformVersions(value, engineVersions)
+			if len(matches) == 0 || matches[0] != engine {
+				continue
+			}
+			if len(matches) > 1 {
+				ambiguous = true
+				value = fmt.Sprintf("%s (also UE %s)", value, formatEngineVersions(matches[1:]))
+			}
+			candidates = append(candidates, value)
+		}
+		sort.Strings(candidates)
+
+		if len(candidates) == 1 && !ambiguous {
+			return candidates[0], nil
+		}
+		if len(candidates) > 0 {
+			return "", errors.Errorf("ambiguous DeploymentPlatforms for UE %s, candidates: %s. Set the value for %q in %s",
+				key, strings.Join(candidates, ", "), key, viper.GetString("deployment-platforms"))
+		}
+	}
+
+	if value, ok := knownDeploymentPlatforms[key]; ok {
+		return value, nil
+	}
+
+	return "", errors.Errorf("failed to find the DeploymentPlatforms value for UE %s. Set the value for %q in %s",
+		key, key, viper.GetString("deployment-platforms"))
+}
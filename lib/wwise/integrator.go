@@ -0,0 +1,344 @@
+package wwise
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/wwise/client"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/mircearoata/wwise-cli/utils"
+	"github.com/pkg/errors"
+)
+
+// IntegrationTarget is where an integration is installed. The install state is kept in its plugins directory.
+type IntegrationTarget interface {
+	PluginsDir() string
+}
+
+// IntegrationOptions are the selections of an integration.
+type IntegrationOptions struct {
+	// Platforms limits the SDK files that are downloaded and copied. Empty means every platform.
+	Platforms []string
+	// Configurations limits the SDK build configurations that are copied. Empty means every configuration.
+	Configurations []string
+	// Plugins are the extra SDK plugins to download.
+	Plugins []string
+	// Link is how the SDK files are put in place. Linked files reference the download cache, which must then be kept.
+	Link install.LinkMode
+	// Settings are the values of the settings specific to the integrator, by name. Lists are comma separated.
+	Settings map[string]string
+}
+
+// IntegrationSetting is a setting specific to an integrator, like the engine to use. Commands take it as a flag of the same name.
+type IntegrationSetting struct {
+	Name    string
+	Default string
+	Usage   string
+	// Target settings are used to find the target, so every command working on a target takes them.
+	// The others only apply to integrating.
+	Target bool
+}
+
+// SDKAsset is an SDK folder installed with the integration. Source is relative to the SDK directory,
+// Destination is slash separated and relative to the directory the integration is installed to.
+type SDKAsset struct {
+	Source              string
+	Destination         string
+	FileMatchExpression string
+}
+
+// Integrator integrates Wwise to the projects of an engine. Integrators are shared, so everything specific
+// to an integration is passed to them, in the target and the options.
+type Integrator interface {
+	// Name selects the integrator, e.g. unreal.
+	Name() string
+	// Product is the product category of the integration.
+	Product() string
+	// SDKProduct is the product category of the SDK installed with the integration, or empty if the integration
+	// ships what it needs of the SDK, in which case only the SDK version it was built with is recorded.
+	SDKProduct() string
+	// Settings are the settings specific to the integrator.
+	Settings() []IntegrationSetting
+	// DetectProject returns the target for a project path and the target settings, or an error if it is not a project of the engine.
+	DetectProject(projectPath string, settings map[string]string) (IntegrationTarget, error)
+	// EngineVersion returns the version of the engine of the target, for display.
+	EngineVersion(target IntegrationTarget) (string, error)
+	// NormalizeOptions checks the options, and returns them the way they are recorded in the install manifest.
+	NormalizeOptions(options IntegrationOptions) (IntegrationOptions, error)
+	// IntegrationFiles selects the files of the integration product to download.
+	IntegrationFiles(target IntegrationTarget, versionInfo product.ProductVersionInfo, options IntegrationOptions) ([]product.File, error)
+	// InstallFiles returns the directory the integration is installed to, and the files of the integration downloaded
+	// to integrationDir that are installed there. Local modifications of mergeable files are merged on upgrades.
+	InstallFiles(target IntegrationTarget, versionInfo product.ProductVersionInfo, integrationDir string, options IntegrationOptions) (string, []install.File, error)
+	// SDKPlatforms returns the platforms whose SDK files are downloaded, or nil for every platform.
+	SDKPlatforms(target IntegrationTarget, versionInfo product.ProductVersionInfo, options IntegrationOptions) ([]string, error)
+	// SDKAssets maps the folders of the downloaded SDK in sdkDir to where they are installed.
+	SDKAssets(target IntegrationTarget, versionInfo product.ProductVersionInfo, sdkDir string, options IntegrationOptions) ([]SDKAsset, error)
+	// Edits returns the edits of the project files applied with the installation of the files.
+	Edits(target IntegrationTarget, versionInfo product.ProductVersionInfo, files []install.File, options IntegrationOptions) ([]install.FileEdit, error)
+	// Installed returns the install manifest of the integration of the target, or nil if there is none.
+	Installed(target IntegrationTarget) (*install.Manifest, error)
+	// Verify checks the integration of the target against what the integration version installs, with VerifyIntegration
+	// and whatever else the integrator set up for it. An empty integration version verifies against the installed version.
+	Verify(target IntegrationTarget, integrationVersion string, wwiseClient *client.WwiseClient) (*install.Verification, error)
+	// Uninstall removes the integration from the target, along with the edits of the project files.
+	Uninstall(target IntegrationTarget, force bool) (*install.UninstallResult, error)
+}
+
+// CompatibilityChecker is implemented by integrators that can tell whether an integration version supports the engine of the target.
+type CompatibilityChecker interface {
+	CheckCompatibility(target IntegrationTarget, versionInfo product.ProductVersionInfo, wwiseClient *client.WwiseClient) error
+}
+
+// IntegrationAdopter is implemented by integrators that detect integrations installed without wwise-cli.
+// Adopt completes the manifest returned by Installed with the files the installed version shipped,
+// so that the files the new version dropped are deleted. It fails if the installed version cannot be resolved.
+type IntegrationAdopter interface {
+	Adopt(target IntegrationTarget, installed *install.Manifest, wwiseClient *client.WwiseClient) error
+}
+
+// SettingsChecker is implemented by integrators whose settings edit the project files. SettingsUpToDate reports
+// whether the project files of the target already have the settings, so that syncing knows to integrate again.
+type SettingsChecker interface {
+	SettingsUpToDate(target IntegrationTarget, settings map[string]string) (bool, error)
+}
+
+// UpgradeChecker is implemented by integrators that know which integration versions support the engine of the target.
+type UpgradeChecker interface {
+	CheckOutdated(target IntegrationTarget, wwiseClient *client.WwiseClient) (*OutdatedReport, error)
+}
+
+var integrators = make(map[string]Integrator)
+
+// RegisterIntegrator makes an integrator available by its name. It is meant to be called from the init function
+// of the package implementing the integrator, and panics if the name is taken.
+func RegisterIntegrator(integrator Integrator) {
+	if _, ok := integrators[integrator.Name()]; ok {
+		panic("integrator " + integrator.Name() + " registered twice")
+	}
+	integrators[integrator.Name()] = integrator
+}
+
+func LookupIntegrator(name string) (Integrator, bool) {
+	integrator, ok := integrators[name]
+	return integrator, ok
+}
+
+func IntegratorNames() []string {
+	var names []string
+	for name := range integrators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlanIntegration downloads everything the integration needs, and plans the file operations that integrate it to the target,
+// with the decisions left to the integrator.
+func PlanIntegration(integrator Integrator, target IntegrationTarget, integrationVersion string, options IntegrationOptions, wwiseClient *client.WwiseClient) (*install.Plan, error) {
+	integrationProduct := product.NewWwiseProduct(wwiseClient, integrator.Product())
+
+	integrationProductVersion, err := integrationProduct.GetVersion(integrationVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get integration version")
+	}
+
+	versionInfo, err := integrationProductVersion.GetInfo()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get wwise manifest")
+	}
+
+	options, err = integrator.NormalizeOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if checker, ok := integrator.(CompatibilityChecker); ok {
+		if err := checker.CheckCompatibility(target, versionInfo, wwiseClient); err != nil {
+			return nil, err
+		}
+	}
+
+	integrationFiles, err := integrator.IntegrationFiles(target, versionInfo, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range integrationFiles {
+		if err := integrationProductVersion.DownloadOrCache(file); err != nil {
+			return nil, errors.Wrap(err, "failed to download integration file")
+		}
+	}
+
+	installDir, files, err := integrator.InstallFiles(target, versionInfo, integrationProductVersion.Dir, options)
+	if err != nil {
+		return nil, err
+	}
+
+	sdkVersion := sdkVersionForIntegration(versionInfo)
+	if integrator.SDKProduct() != "" {
+		sdkProductVersion, err := product.NewWwiseProduct(wwiseClient, integrator.SDKProduct()).GetVersion(sdkVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get sdk version")
+		}
+		sdkVersion = sdkProductVersion.VersionId
+
+		downloadPlatforms, err := integrator.SDKPlatforms(target, versionInfo, options)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get sdk platforms")
+		}
+
+		err = downloadSDKFiles(sdkProductVersion, downloadPlatforms, options.Plugins)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to download sdk")
+		}
+
+		sdkDir := filepath.Join(sdkProductVersion.Dir, "SDK")
+		sdkAssets, err := integrator.SDKAssets(target, versionInfo, sdkDir, options)
+		if err != nil {
+			return nil, err
+		}
+
+		sdkFiles, err := listSDKAssetFiles(sdkDir, sdkAssets, options.Configurations)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, sdkFiles...)
+	}
+
+	installed, err := integrator.Installed(target)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if installed != nil {
+		managed, err := install.ReadManifest(installDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read install manifest")
+		}
+		if managed == nil {
+			adopter, ok := integrator.(IntegrationAdopter)
+			if !ok {
+				return nil, errors.New("the integration in " + installDir + " was not installed by wwise-cli, remove it before integrating")
+			}
+			if err := adopter.Adopt(target, installed, wwiseClient); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	plan, err := install.NewPlan(installDir, files, install.Manifest{
+		IntegrationVersion: integrationProductVersion.VersionId,
+		SdkVersion:         sdkVersion,
+		Platforms:          options.Platforms,
+		Configurations:     options.Configurations,
+		Plugins:            options.Plugins,
+		Link:               options.Link,
+	}, installed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan integration")
+	}
+
+	edits, err := integrator.Edits(target, versionInfo, files, options)
+	if err != nil {
+		return nil, err
+	}
+	plan.Edits = append(plan.Edits, edits...)
+
+	return plan, nil
+}
+
+// listIntegrationFiles lists the files of the directories of a downloaded integration, installed to the plugins directory as they are.
+func listIntegrationFiles(integrationDir string) ([]install.File, error) {
+	integrationAssets, err := os.ReadDir(integrationDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read integration cache path")
+	}
+
+	var files []install.File
+	for _, entry := range integrationAssets {
+		if entry.IsDir() {
+			assetFiles, err := listFiles(filepath.Join(integrationDir, entry.Name()), entry.Name(), nil)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list integration asset: %s", entry.Name())
+			}
+			files = append(files, assetFiles...)
+		}
+	}
+	return files, nil
+}
+
+// listSDKAssetFiles lists the files of the SDK assets, in the SDK directory, that are installed with the selected configurations.
+func listSDKAssetFiles(sdkDir string, sdkAssets []SDKAsset, configurations []string) ([]install.File, error) {
+	var files []install.File
+	for _, sdkAsset := range sdkAssets {
+		fileMatch, err := utils.CompileGlob(sdkAsset.FileMatchExpression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse file expression of %s", sdkAsset.Source)
+		}
+		assetFiles, err := sdkAssetFiles(filepath.Join(sdkDir, sdkAsset.Source), sdkAsset.Destination, fileMatch, configurations)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list third party files")
+		}
+		files = append(files, assetFiles...)
+	}
+	return files, nil
+}
+
+// InstallIntegration plans the integration of the integration version to the target, and applies the plan.
+func InstallIntegration(integrator Integrator, target IntegrationTarget, integrationVersion string, options IntegrationOptions, wwiseClient *client.WwiseClient) (*install.Result, error) {
+	plan, err := PlanIntegration(integrator, target, integrationVersion, options, wwiseClient)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := plan.Apply()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply integration plan")
+	}
+
+	return result, nil
+}
+
+// VerifyIntegration checks the integration of the target against what its version installs, with the recorded selection.
+// An empty integration version verifies against the installed version. Integrators implement Verify with it.
+func VerifyIntegration(integrator Integrator, target IntegrationTarget, integrationVersion string, wwiseClient *client.WwiseClient) (*install.Verification, error) {
+	installed, err := integrator.Installed(target)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+
+	options := IntegrationOptions{}
+	if installed != nil {
+		if integrationVersion == "" {
+			integrationVersion = installed.IntegrationVersion
+		}
+		options.Platforms = installed.Platforms
+		options.Configurations = installed.Configurations
+		options.Plugins = installed.Plugins
+		options.Link = installed.Link
+	}
+	if integrationVersion == "" {
+		return nil, errors.New("failed to find an integration to verify in " + target.PluginsDir())
+	}
+
+	plan, err := PlanIntegration(integrator, target, integrationVersion, options, wwiseClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan expected installation")
+	}
+
+	return plan.Verify(), nil
+}
+
+// settingList splits the value of a list setting.
+func settingList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
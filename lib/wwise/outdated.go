@@ -0,0 +1,220 @@
+package wwise
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise/client"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+type ReleaseLink struct {
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+}
+
+// UnrealUpgrade is an integration release that the installed integration can be upgraded to.
+type UnrealUpgrade struct {
+	IntegrationVersion string        `json:"integrationVersion"`
+	SdkVersion         string        `json:"sdkVersion"`
+	Nickname           string        `json:"nickname,omitempty"`
+	Stable             bool          `json:"stable"`
+	Documentation      []ReleaseLink `json:"documentation,omitempty"`
+	Links              []ReleaseLink `json:"links,omitempty"`
+}
+
+// OutdatedReport lists the integration releases newer than the installed one that support the engine.
+type OutdatedReport struct {
+	Target             string `json:"target"`
+	EngineVersion      string `json:"engineVersion"`
+	IntegrationVersion string `json:"integrationVersion"`
+	SdkVersion         string `json:"sdkVersion,omitempty"`
+	// SdkUpgrade is the SDK version of the installed integration release, if the installed SDK is older.
+	SdkUpgrade string `json:"sdkUpgrade,omitempty"`
+	// UpgradesUnknown is why the upgrades could not be found, if the installed version cannot be compared.
+	UpgradesUnknown string          `json:"upgradesUnknown,omitempty"`
+	Upgrades        []UnrealUpgrade `json:"upgrades"`
+}
+
+// releaseLinks extracts the links of the documentation or links of a release. Their format is not documented,
+// so plain URLs and objects with a url, link or href are accepted, titled by their title, name or label.
+func releaseLinks(values []interface{}) []ReleaseLink {
+	var links []ReleaseLink
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			links = append(links, ReleaseLink{URL: v})
+		case map[string]interface{}:
+			link := ReleaseLink{}
+			for _, key := range []string{"url", "link", "href"} {
+				if url, ok := v[key].(string); ok && url != "" {
+					link.URL = url
+					break
+				}
+			}
+			for _, key := range []string{"title", "name", "label"} {
+				if title, ok := v[key].(string); ok && title != "" {
+					link.Title = title
+					break
+				}
+			}
+			if link.URL != "" {
+				links = append(links, link)
+			}
+		}
+	}
+	return links
+}
+
+func bundleSdkVersion(bundle product.Bundle) string {
+	return fmt.Sprintf("%d.%d.%d.%d", bundle.Version.Year, bundle.Version.Major, bundle.Version.Minor, bundle.ProductDependentData.WwiseSdkBuild)
+}
+
+// sdkOlder returns whether the SDK version is older than the other one. Versions that cannot be compared are not older.
+func sdkOlder(sdkVersion string, other string) bool {
+	version, err := parseIntegrationVersion(sdkVersion)
+	if err != nil {
+		return false
+	}
+	otherVersion, err := parseIntegrationVersion(other)
+	if err != nil {
+		return false
+	}
+	return compareVersions(version, otherVersion) < 0
+}
+
+// checkOutdatedUnreal finds the integration releases the integration of the target can be upgraded to,
+// and whether its SDK is older than the one of its release.
+func checkOutdatedUnreal(target UnrealTarget, wwiseClient *client.WwiseClient) (*OutdatedReport, error) {
+	pluginsDir := target.PluginsDir()
+	installed, err := installedUnreal(pluginsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if installed == nil {
+		return nil, errors.New("failed to find a Wwise integration in " + pluginsDir)
+	}
+
+	engineVersion, err := target.GetEngineVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OutdatedReport{
+		Target:             pluginsDir,
+		EngineVersion:      fmt.Sprintf("%d.%d", engineVersion.Major, engineVersion.Minor),
+		IntegrationVersion: installed.IntegrationVersion,
+		SdkVersion:         installed.SdkVersion,
+		Upgrades:           []UnrealUpgrade{},
+	}
+	if !target.IsEngine() {
+		report.Target = target.Project
+	}
+	if report.SdkVersion == "" {
+		report.SdkVersion, _ = readSDKVersionHeader(filepath.Join(pluginsDir, "Wwise", "ThirdParty", "include", "AK", "AkWwiseSDKVersion.h"))
+	}
+
+	matrix, err := UnrealCompatibilityMatrix(wwiseClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find newer integration versions")
+	}
+
+	current, err := parseIntegrationVersion(installed.IntegrationVersion)
+	if err != nil {
+		if errors.Cause(err) != ErrUnknownIntegrationVersion {
+			return nil, err
+		}
+		report.UpgradesUnknown = err.Error()
+		return report, nil
+	}
+
+	for _, integration := range matrix {
+		if compareVersions(integration.Version, current) == 0 {
+			if releaseSdk := bundleSdkVersion(integration.Bundle); sdkOlder(report.SdkVersion, releaseSdk) {
+				report.SdkUpgrade = releaseSdk
+			}
+			break
+		}
+	}
+
+	for _, integration := range newerIntegrations(matrix, current, engineVersion) {
+		bundle := integration.Bundle
+		report.Upgrades = append(report.Upgrades, UnrealUpgrade{
+			IntegrationVersion: integration.IntegrationVersion,
+			SdkVersion:         bundleSdkVersion(bundle),
+			Nickname:           bundle.Version.Nickname,
+			Stable:             bundle.Stable != 0,
+			Documentation:      releaseLinks(bundle.Documentation),
+			Links:              releaseLinks(bundle.Links),
+		})
+	}
+
+	return report, nil
+}
+
+func writeMarkdownLinks(w io.Writer, links []ReleaseLink) {
+	for _, link := range links {
+		if link.Title == "" {
+			fmt.Fprintf(w, "  - <%s>\n", link.URL)
+		} else {
+			fmt.Fprintf(w, "  - [%s](%s)\n", link.Title, link.URL)
+		}
+	}
+}
+
+// WriteMarkdown writes the report as Markdown, e.g. for the description of an upgrade ticket.
+func (r *OutdatedReport) WriteMarkdown(w io.Writer) {
+	fmt.Fprintf(w, "# Wwise upgrades for %s\n\n", r.Target)
+	fmt.Fprintf(w, "- Unreal Engine: %s\n", r.EngineVersion)
+	fmt.Fprintf(w, "- Installed integration: %s\n", r.IntegrationVersion)
+	if r.SdkVersion != "" {
+		fmt.Fprintf(w, "- Installed SDK: %s\n", r.SdkVersion)
+	}
+	fmt.Fprintln(w)
+
+	if r.SdkUpgrade != "" {
+		fmt.Fprintf(w, "The installed SDK is older than SDK %s of integration %s, integrate it again to upgrade the SDK.\n\n", r.SdkUpgrade, r.IntegrationVersion)
+	}
+
+	if r.UpgradesUnknown != "" {
+		fmt.Fprintf(w, "The upgrades are unknown: %s.\n", r.UpgradesUnknown)
+		return
+	}
+
+	if len(r.Upgrades) == 0 {
+		if r.SdkUpgrade != "" {
+			fmt.Fprintf(w, "No newer integration version supports the engine.\n")
+		} else {
+			fmt.Fprintf(w, "The integration is up to date.\n")
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "| Integration | SDK | Nickname | Stable |\n")
+	fmt.Fprintf(w, "| --- | --- | --- | --- |\n")
+	for _, upgrade := range r.Upgrades {
+		stable := "no"
+		if upgrade.Stable {
+			stable = "yes"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s |\n", upgrade.IntegrationVersion, upgrade.SdkVersion, strings.ReplaceAll(upgrade.Nickname, "|", "\\|"), stable)
+	}
+
+	for _, upgrade := range r.Upgrades {
+		if len(upgrade.Documentation) == 0 && len(upgrade.Links) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n## %s\n\n", upgrade.IntegrationVersion)
+		if len(upgrade.Documentation) > 0 {
+			fmt.Fprintf(w, "- Documentation:\n")
+			writeMarkdownLinks(w, upgrade.Documentation)
+		}
+		if len(upgrade.Links) > 0 {
+			fmt.Fprintf(w, "- Links:\n")
+			writeMarkdownLinks(w, upgrade.Links)
+		}
+	}
+}
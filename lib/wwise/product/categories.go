@@ -0,0 +1,72 @@
+package product
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise/client"
+	"github.com/pkg/errors"
+)
+
+// Category describes a product category wwise-cli knows default filters for.
+// The list is not exhaustive, any category offered by the API can be used, it just has no default filters.
+type Category struct {
+	Name           string
+	Description    string
+	DefaultFilters []GroupFilter
+}
+
+var Categories = []Category{
+	{
+		Name:           "wwise",
+		Description:    "Wwise SDK and authoring tools",
+		DefaultFilters: []GroupFilter{{GroupID: "Packages", GroupValues: []string{"SDK"}}},
+	},
+	{
+		Name:           "unrealintegration",
+		Description:    "Wwise Unreal Engine integration",
+		DefaultFilters: []GroupFilter{{GroupID: "Packages", GroupValues: []string{"Unreal"}}},
+	},
+	{
+		Name:           "unityintegration",
+		Description:    "Wwise Unity integration",
+		DefaultFilters: []GroupFilter{{GroupID: "Packages", GroupValues: []string{"Unity"}}},
+	},
+}
+
+func FindCategory(name string) (Category, bool) {
+	for _, category := range Categories {
+		if category.Name == name {
+			return category, true
+		}
+	}
+	return Category{}, false
+}
+
+// ListCategories returns the categories of the products the API offers, taken from the ids of their versions, e.g. wwise.2023_1_0_8367.
+func ListCategories(wwiseClient *client.WwiseClient) ([]string, error) {
+	payload, err := wwiseClient.SendRequest("GET", "/products/versions/", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get product versions")
+	}
+
+	var data struct {
+		Data ProductInfo `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal product versions")
+	}
+
+	var categories []string
+	seen := make(map[string]bool)
+	for _, bundle := range data.Data.Bundles {
+		category := strings.SplitN(bundle.ID, ".", 2)[0]
+		if category != "" && !seen[category] {
+			seen[category] = true
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
@@ -0,0 +1,379 @@
+package product
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// StringsDiff is the difference between two sets of values.
+type StringsDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+func (d StringsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+func diffStrings(from []string, to []string) StringsDiff {
+	fromSet := make(map[string]bool)
+	for _, value := range from {
+		fromSet[value] = true
+	}
+	toSet := make(map[string]bool)
+	for _, value := range to {
+		toSet[value] = true
+	}
+
+	var diff StringsDiff
+	for value := range toSet {
+		if !fromSet[value] {
+			diff.Added = append(diff.Added, value)
+		}
+	}
+	for value := range fromSet {
+		if !toSet[value] {
+			diff.Removed = append(diff.Removed, value)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+type GroupDiff struct {
+	ID string `json:"id"`
+	StringsDiff
+}
+
+// FileSizeChange is a file whose size changed. Files are matched by their group values, as their names contain the version.
+type FileSizeChange struct {
+	Groups               string `json:"groups"`
+	FromSize             int    `json:"fromSize"`
+	ToSize               int    `json:"toSize"`
+	FromUncompressedSize int    `json:"fromUncompressedSize"`
+	ToUncompressedSize   int    `json:"toUncompressedSize"`
+}
+
+// VersionDiff is the difference between the version info of two versions of a product.
+type VersionDiff struct {
+	From                    string           `json:"from"`
+	To                      string           `json:"to"`
+	Groups                  []GroupDiff      `json:"groups,omitempty"`
+	SupportedPlatforms      StringsDiff      `json:"supportedPlatforms"`
+	SupportedUnrealVersions StringsDiff      `json:"supportedUnrealVersions"`
+	Files                   StringsDiff      `json:"files"`
+	FileSizes               []FileSizeChange `json:"fileSizes,omitempty"`
+	PlatformFolders         StringsDiff      `json:"platformFolders"`
+	SdkPlatformFolders      StringsDiff      `json:"sdkPlatformFolders"`
+	Eulas                   StringsDiff      `json:"eulas"`
+}
+
+func (d VersionDiff) Empty() bool {
+	return len(d.Groups) == 0 && d.SupportedPlatforms.Empty() && d.SupportedUnrealVersions.Empty() && d.Files.Empty() &&
+		len(d.FileSizes) == 0 && d.PlatformFolders.Empty() && d.SdkPlatformFolders.Empty() && d.Eulas.Empty()
+}
+
+func fileGroupsKey(file File) string {
+	var groups []string
+	for _, group := range file.Groups {
+		groups = append(groups, group.GroupID+"="+group.GroupValueID)
+	}
+	sort.Strings(groups)
+	return strings.Join(groups, ", ")
+}
+
+func platformFolderEntries(folders *PlatformFolders) []string {
+	if folders == nil {
+		return nil
+	}
+	var entries []string
+	for _, folder := range folders.Mandatory {
+		entries = append(entries, folder+" (mandatory)")
+	}
+	for _, folder := range folders.Optional {
+		entries = append(entries, folder+" (optional)")
+	}
+	return entries
+}
+
+func describeEngineVersion(version *SdkPlatformFolderInfoEngineVersion) string {
+	return version.Major + "." + version.Minor
+}
+
+func sdkPlatformFolderEntries(folders *SdkPlatformFolders) []string {
+	if folders == nil {
+		return nil
+	}
+	var entries []string
+	for platform, infos := range *folders {
+		for _, info := range infos {
+			entry := fmt.Sprintf("%s: %s -> %s", platform, info.Source, info.Destination)
+			if info.FileMatchExpression != "" {
+				entry += " matching " + info.FileMatchExpression
+			}
+			if info.Optional {
+				entry += ", optional"
+			}
+			if info.SinceEngine != nil {
+				entry += ", since UE " + describeEngineVersion(info.SinceEngine)
+			}
+			if info.UntilEngine != nil {
+				entry += ", until UE " + describeEngineVersion(info.UntilEngine)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// DiffVersionInfo compares the version info of two versions of a product.
+func DiffVersionInfo(from ProductVersionInfo, to ProductVersionInfo) VersionDiff {
+	diff := VersionDiff{
+		From: from.ID,
+		To:   to.ID,
+	}
+
+	var groupIds []string
+	seenGroups := make(map[string]bool)
+	for _, group := range append(append([]GroupList{}, from.Groups...), to.Groups...) {
+		if !seenGroups[group.ID] {
+			seenGroups[group.ID] = true
+			groupIds = append(groupIds, group.ID)
+		}
+	}
+	for _, groupId := range groupIds {
+		groupDiff := diffStrings(from.GroupValues(groupId), to.GroupValues(groupId))
+		if !groupDiff.Empty() {
+			diff.Groups = append(diff.Groups, GroupDiff{ID: groupId, StringsDiff: groupDiff})
+		}
+	}
+
+	diff.SupportedPlatforms = diffStrings(from.ProductDependentData.SupportedPlatforms, to.ProductDependentData.SupportedPlatforms)
+
+	unrealVersions := func(info ProductVersionInfo) []string {
+		var versions []string
+		for _, version := range info.ProductDependentData.SupportedUnrealVersions {
+			versions = append(versions, fmt.Sprintf("%d.%d", version.Major, version.Minor))
+		}
+		return versions
+	}
+	diff.SupportedUnrealVersions = diffStrings(unrealVersions(from), unrealVersions(to))
+
+	fromFiles := make(map[string]File)
+	var fromKeys []string
+	for _, file := range from.Files {
+		key := fileGroupsKey(file)
+		fromFiles[key] = file
+		fromKeys = append(fromKeys, key)
+	}
+	var toKeys []string
+	for _, file := range to.Files {
+		key := fileGroupsKey(file)
+		toKeys = append(toKeys, key)
+		if fromFile, ok := fromFiles[key]; ok && (fromFile.Size != file.Size || fromFile.UncompressedSize != file.UncompressedSize) {
+			diff.FileSizes = append(diff.FileSizes, FileSizeChange{
+				Groups:               key,
+				FromSize:             fromFile.Size,
+				ToSize:               file.Size,
+				FromUncompressedSize: fromFile.UncompressedSize,
+				ToUncompressedSize:   file.UncompressedSize,
+			})
+		}
+	}
+	diff.Files = diffStrings(fromKeys, toKeys)
+	sort.Slice(diff.FileSizes, func(i, j int) bool {
+		return diff.FileSizes[i].Groups < diff.FileSizes[j].Groups
+	})
+
+	diff.PlatformFolders = diffStrings(platformFolderEntries(from.ProductDependentData.PlatformFolders), platformFolderEntries(to.ProductDependentData.PlatformFolders))
+	diff.SdkPlatformFolders = diffStrings(sdkPlatformFolderEntries(from.ProductDependentData.SdkPlatformFolders), sdkPlatformFolderEntries(to.ProductDependentData.SdkPlatformFolders))
+
+	eulas := func(info ProductVersionInfo) []string {
+		var names []string
+		for _, eula := range info.Eulas {
+			names = append(names, fmt.Sprintf("%s (%s)", eula.DisplayName, eula.FileName))
+		}
+		return names
+	}
+	diff.Eulas = diffStrings(eulas(from), eulas(to))
+
+	return diff
+}
+
+func printStringsDiff(w io.Writer, title string, diff StringsDiff) {
+	if diff.Empty() {
+		return
+	}
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, value := range diff.Added {
+		fmt.Fprintf(w, "  + %s\n", value)
+	}
+	for _, value := range diff.Removed {
+		fmt.Fprintf(w, "  - %s\n", value)
+	}
+}
+
+// PrintSummary writes a human-readable summary of the differences.
+func (d VersionDiff) PrintSummary(w io.Writer) {
+	fmt.Fprintf(w, "Comparing %s to %s\n", d.From, d.To)
+	if d.Empty() {
+		fmt.Fprintf(w, "  No differences\n")
+		return
+	}
+
+	for _, group := range d.Groups {
+		printStringsDiff(w, "Group "+group.ID, group.StringsDiff)
+	}
+	printStringsDiff(w, "Supported platforms", d.SupportedPlatforms)
+	printStringsDiff(w, "Supported Unreal Engine versions", d.SupportedUnrealVersions)
+	printStringsDiff(w, "Files", d.Files)
+	if len(d.FileSizes) > 0 {
+		fmt.Fprintf(w, "\nFile sizes:\n")
+		for _, change := range d.FileSizes {
+			fmt.Fprintf(w, "  ~ %s: %d -> %d bytes (%d -> %d uncompressed)\n", change.Groups, change.FromSize, change.ToSize, change.FromUncompressedSize, change.ToUncompressedSize)
+		}
+	}
+	printStringsDiff(w, "Platform folders", d.PlatformFolders)
+	printStringsDiff(w, "SDK platform folders", d.SdkPlatformFolders)
+	printStringsDiff(w, "EULAs", d.Eulas)
+}
+
+// ContentDiff is the difference between the extracted files of two cached versions.
+type ContentDiff struct {
+	StringsDiff
+	// Changed are the files present in both versions with a different content.
+	Changed []string `json:"changed,omitempty"`
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", errors.Wrap(err, "failed to hash file")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sameContent returns whether two files of the same size have the same content.
+func sameContent(a string, b string) (bool, error) {
+	aHash, err := hashFile(a)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to hash %s", a)
+	}
+	bHash, err := hashFile(b)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to hash %s", b)
+	}
+	return aHash == bHash, nil
+}
+
+func cachedFiles(dir string) (map[string]int64, error) {
+	files := make(map[string]int64)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if relPath == "info.json" || relPath == "version.json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[relPath] = info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list files of %s", dir)
+	}
+	return files, nil
+}
+
+// DiffContent compares the files extracted to the cache for two versions. Only the downloaded files are compared.
+func DiffContent(from *WwiseProductVersion, to *WwiseProductVersion) (*ContentDiff, error) {
+	fromFiles, err := cachedFiles(from.Dir)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := cachedFiles(to.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromPaths, toPaths []string
+	for p := range fromFiles {
+		fromPaths = append(fromPaths, p)
+	}
+	diff := &ContentDiff{}
+	for p, size := range toFiles {
+		toPaths = append(toPaths, p)
+		fromSize, ok := fromFiles[p]
+		if !ok {
+			continue
+		}
+		// Files of different sizes differ, the others are compared by hash
+		if fromSize == size {
+			same, err := sameContent(filepath.Join(from.Dir, filepath.FromSlash(p)), filepath.Join(to.Dir, filepath.FromSlash(p)))
+			if err != nil {
+				return nil, err
+			}
+			if same {
+				continue
+			}
+		}
+		diff.Changed = append(diff.Changed, p)
+	}
+	diff.StringsDiff = diffStrings(fromPaths, toPaths)
+	sort.Strings(diff.Changed)
+	return diff, nil
+}
+
+// PrintSummary writes the differences grouped by directory, so that whole platform folders show up as a single line.
+func (d *ContentDiff) PrintSummary(w io.Writer) {
+	fmt.Fprintf(w, "\nContent: %d added, %d removed, %d changed\n", len(d.Added), len(d.Removed), len(d.Changed))
+	for _, section := range []struct {
+		symbol string
+		files  []string
+	}{{"+", d.Added}, {"-", d.Removed}, {"~", d.Changed}} {
+		var dirs []string
+		dirFiles := make(map[string][]string)
+		for _, file := range section.files {
+			dir := path.Dir(file)
+			if _, ok := dirFiles[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+			dirFiles[dir] = append(dirFiles[dir], file)
+		}
+		for _, dir := range dirs {
+			if len(dirFiles[dir]) == 1 {
+				fmt.Fprintf(w, "  %s %s\n", section.symbol, dirFiles[dir][0])
+			} else {
+				fmt.Fprintf(w, "  %s %s/ (%d files)\n", section.symbol, dir, len(dirFiles[dir]))
+			}
+		}
+	}
+}
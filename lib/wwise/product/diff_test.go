@@ -0,0 +1,159 @@
+package product
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func sdkFile(version string, platform string, size int) File {
+	return File{
+		Name: "Wwise_" + version + "_SDK." + platform + ".tar.xz",
+		Groups: []Group{
+			{GroupID: "Packages", GroupValueID: "SDK"},
+			{GroupID: "DeploymentPlatforms", GroupValueID: platform},
+		},
+		Size:             size,
+		UncompressedSize: size * 4,
+	}
+}
+
+func deploymentPlatforms(platforms ...string) GroupList {
+	group := GroupList{ID: "DeploymentPlatforms"}
+	for _, platform := range platforms {
+		group.Values = append(group.Values, Values{ID: platform})
+	}
+	return group
+}
+
+func TestDiffVersionInfo(t *testing.T) {
+	from := ProductVersionInfo{
+		ID: "wwise.2023_1_0_8367",
+		Files: []File{
+			sdkFile("2023.1.0.8367", "Windows_vc160", 100),
+			sdkFile("2023.1.0.8367", "Android", 200),
+			sdkFile("2023.1.0.8367", "Linux", 300),
+		},
+		Groups: []GroupList{deploymentPlatforms("Windows_vc160", "Android", "Linux")},
+		ProductDependentData: ProductDependentData{
+			SupportedPlatforms: []string{"Windows", "Android", "Linux"},
+			SdkPlatformFolders: &SdkPlatformFolders{
+				"Windows": {{Source: "x64_vc160", Destination: "x64_vc160", FileMatchExpression: "*", Optional: true}},
+			},
+		},
+		Eulas: []Eula{{DisplayName: "Wwise EULA", FileName: "eula.txt"}},
+	}
+	to := ProductVersionInfo{
+		ID: "wwise.2023_1_1_8417",
+		Files: []File{
+			sdkFile("2023.1.1.8417", "Windows_vc160", 150),
+			sdkFile("2023.1.1.8417", "Linux", 300),
+			sdkFile("2023.1.1.8417", "Windows_vc170", 400),
+		},
+		Groups: []GroupList{deploymentPlatforms("Windows_vc160", "Windows_vc170", "Linux")},
+		ProductDependentData: ProductDependentData{
+			SupportedPlatforms: []string{"Windows", "Linux"},
+			SdkPlatformFolders: &SdkPlatformFolders{
+				"Windows": {
+					{Source: "x64_vc160", Destination: "x64_vc160", FileMatchExpression: "*", Optional: true, UntilEngine: &SdkPlatformFolderInfoEngineVersion{Major: "5", Minor: "2"}},
+					{Source: "x64_vc170", Destination: "x64_vc170", FileMatchExpression: "*", Optional: true, SinceEngine: &SdkPlatformFolderInfoEngineVersion{Major: "5", Minor: "3"}},
+				},
+			},
+		},
+		Eulas: []Eula{{DisplayName: "Wwise EULA", FileName: "eula.txt"}},
+	}
+
+	diff := DiffVersionInfo(from, to)
+	if diff.From != from.ID || diff.To != to.ID {
+		t.Errorf("compared %s to %s", diff.From, diff.To)
+	}
+	if want := []GroupDiff{{ID: "DeploymentPlatforms", StringsDiff: StringsDiff{Added: []string{"Windows_vc170"}, Removed: []string{"Android"}}}}; !reflect.DeepEqual(diff.Groups, want) {
+		t.Errorf("groups %+v, want %+v", diff.Groups, want)
+	}
+	if want := (StringsDiff{Removed: []string{"Android"}}); !reflect.DeepEqual(diff.SupportedPlatforms, want) {
+		t.Errorf("supported platforms %+v, want %+v", diff.SupportedPlatforms, want)
+	}
+
+	// Files are matched by their groups, their names differ in every version
+	wantFiles := StringsDiff{
+		Added:   []string{"DeploymentPlatforms=Windows_vc170, Packages=SDK"},
+		Removed: []string{"DeploymentPlatforms=Android, Packages=SDK"},
+	}
+	if !reflect.DeepEqual(diff.Files, wantFiles) {
+		t.Errorf("files %+v, want %+v", diff.Files, wantFiles)
+	}
+	wantSizes := []FileSizeChange{{Groups: "DeploymentPlatforms=Windows_vc160, Packages=SDK", FromSize: 100, ToSize: 150, FromUncompressedSize: 400, ToUncompressedSize: 600}}
+	if !reflect.DeepEqual(diff.FileSizes, wantSizes) {
+		t.Errorf("file sizes %+v, want %+v", diff.FileSizes, wantSizes)
+	}
+
+	wantFolders := StringsDiff{
+		Added: []string{
+			"Windows: x64_vc160 -> x64_vc160 matching *, optional, until UE 5.2",
+			"Windows: x64_vc170 -> x64_vc170 matching *, optional, since UE 5.3",
+		},
+		Removed: []string{"Windows: x64_vc160 -> x64_vc160 matching *, optional"},
+	}
+	if !reflect.DeepEqual(diff.SdkPlatformFolders, wantFolders) {
+		t.Errorf("SDK platform folders %+v, want %+v", diff.SdkPlatformFolders, wantFolders)
+	}
+	if !diff.Eulas.Empty() || !diff.PlatformFolders.Empty() || !diff.SupportedUnrealVersions.Empty() {
+		t.Errorf("unexpected differences %+v", diff)
+	}
+
+	if diff := DiffVersionInfo(from, from); !diff.Empty() {
+		t.Errorf("a version differs from itself: %+v", diff)
+	}
+}
+
+func cachedVersion(t *testing.T, files map[string]string) *WwiseProductVersion {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &WwiseProductVersion{Dir: dir}
+}
+
+func TestDiffContent(t *testing.T) {
+	from := cachedVersion(t, map[string]string{
+		"info.json":                          `{"files": ["a"]}`,
+		"version.json":                       `{"id": "wwise.2023_1_0_8367"}`,
+		"SDK/include/AK/AkWwiseSDKVersion.h": "2023.1.0",
+		"SDK/include/AK/SoundEngine/AkSoundEngine.h":  "same",
+		"SDK/x64_vc160/Release/lib/AkSoundEngine.lib": "lib 1",
+		"SDK/Android/Release/lib/libAkSoundEngine.a":  "android",
+	})
+	to := cachedVersion(t, map[string]string{
+		"info.json":                          `{"files": ["a", "b"]}`,
+		"version.json":                       `{"id": "wwise.2023_1_1_8417"}`,
+		"SDK/include/AK/AkWwiseSDKVersion.h": "2023.1.1",
+		"SDK/include/AK/SoundEngine/AkSoundEngine.h":  "same",
+		"SDK/x64_vc160/Release/lib/AkSoundEngine.lib": "lib 2, larger",
+		"SDK/x64_vc170/Release/lib/AkSoundEngine.lib": "lib 2",
+		"SDK/x64_vc170/Profile/lib/AkSoundEngine.lib": "lib 2",
+	})
+
+	diff, err := DiffContent(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ContentDiff{
+		StringsDiff: StringsDiff{
+			Added:   []string{"SDK/x64_vc170/Profile/lib/AkSoundEngine.lib", "SDK/x64_vc170/Release/lib/AkSoundEngine.lib"},
+			Removed: []string{"SDK/Android/Release/lib/libAkSoundEngine.a"},
+		},
+		// Files of the same size are compared by content, files of a different size always differ
+		Changed: []string{"SDK/include/AK/AkWwiseSDKVersion.h", "SDK/x64_vc160/Release/lib/AkSoundEngine.lib"},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("content diff %+v, want %+v", diff, want)
+	}
+}
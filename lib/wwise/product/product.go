@@ -42,9 +42,27 @@ func (p *WwiseProduct) GetInfo() (ProductInfo, error) {
 	return data.Data, nil
 }
 
+func (p *WwiseProduct) versionCacheDir(version string) string {
+	return filepath.Join(viper.GetString("cache-dir"), p.ProductName, strings.TrimPrefix(version, p.ProductName+"."))
+}
+
+// IsCached returns whether files of the version were downloaded, without creating its cache directory.
+func (p *WwiseProduct) IsCached(version string) bool {
+	infoData, err := os.ReadFile(filepath.Join(p.versionCacheDir(version), "info.json"))
+	if err != nil {
+		return false
+	}
+	// Getting a version creates its info before anything is downloaded
+	var info WwiseVersionDownloadedInfo
+	if err := json.Unmarshal(infoData, &info); err != nil {
+		return false
+	}
+	return len(info.Files) > 0
+}
+
 func (p *WwiseProduct) GetVersion(version string) (*WwiseProductVersion, error) {
+	cacheDir := p.versionCacheDir(version)
 	version = strings.TrimPrefix(version, p.ProductName+".")
-	cacheDir := filepath.Join(viper.GetString("cache-dir"), p.ProductName, version)
 	pv := &WwiseProductVersion{
 		Product:   p,
 		VersionId: version,
@@ -130,14 +148,38 @@ func (v *WwiseProductVersion) GetInfo() (ProductVersionInfo, error) {
 	}
 
 	var data struct {
-		Data ProductVersionInfo `json:"data"`
+		Data json.RawMessage `json:"data"`
 	}
 	err = json.Unmarshal([]byte(payload), &data)
 	if err != nil {
 		return ProductVersionInfo{}, errors.Wrap(err, "failed to unmarshal product version info")
 	}
 
-	return data.Data, nil
+	var info ProductVersionInfo
+	if err := json.Unmarshal(data.Data, &info); err != nil {
+		return ProductVersionInfo{}, errors.Wrap(err, "failed to unmarshal product version info")
+	}
+
+	// Keep the info for the commands that work offline
+	if err := os.WriteFile(filepath.Join(v.Dir, "version.json"), data.Data, 0644); err != nil {
+		return ProductVersionInfo{}, errors.Wrap(err, "failed to cache product version info")
+	}
+
+	return info, nil
+}
+
+// CachedInfo returns the product version info of the last GetInfo, without the Wwise API.
+func (v *WwiseProductVersion) CachedInfo() (ProductVersionInfo, error) {
+	infoData, err := os.ReadFile(filepath.Join(v.Dir, "version.json"))
+	if err != nil {
+		return ProductVersionInfo{}, errors.Wrap(err, "failed to read cached product version info")
+	}
+
+	var info ProductVersionInfo
+	if err := json.Unmarshal(infoData, &info); err != nil {
+		return ProductVersionInfo{}, errors.Wrap(err, "failed to unmarshal cached product version info")
+	}
+	return info, nil
 }
 
 func (v *WwiseProductVersion) DownloadOrCache(file File) error {
@@ -168,6 +210,22 @@ func (v *WwiseProductVersion) DownloadOrCache(file File) error {
 	return nil
 }
 
+// IsGroupDownloaded returns whether a file of the group value was downloaded.
+func (v *WwiseProductVersion) IsGroupDownloaded(groupId string, groupValue string) bool {
+	return v.downloadedInfo.IsGroupDownloaded(groupId, groupValue)
+}
+
+// DownloadedGroupValues returns the values of the group of the downloaded files.
+func (v *WwiseProductVersion) DownloadedGroupValues(groupId string) []string {
+	var values []string
+	for _, group := range v.downloadedInfo.Groups {
+		if group.GroupID == groupId {
+			values = append(values, group.GroupValueID)
+		}
+	}
+	return values
+}
+
 type WwiseVersionDownloadedInfo struct {
 	Files  []string `json:"files"`
 	Groups []Group  `json:"groups"`
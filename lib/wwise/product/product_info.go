@@ -38,3 +38,16 @@ func (pvi ProductVersionInfo) FindFilesByGroups(groupsFilters []GroupFilter) []F
 	}
 	return files
 }
+
+func (pvi ProductVersionInfo) GroupValues(groupId string) []string {
+	var values []string
+	for _, group := range pvi.Groups {
+		if group.ID != groupId {
+			continue
+		}
+		for _, value := range group.Values {
+			values = append(values, value.ID)
+		}
+	}
+	return values
+}
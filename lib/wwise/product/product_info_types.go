@@ -23,7 +23,7 @@ type SdkPlatformFoldersInfo struct {
 	Optional            bool                                `json:"optional"`
 	Source              string                              `json:"source"`
 	UntilEngine         *SdkPlatformFolderInfoEngineVersion `json:"untilEngine,omitempty"`
-	SinceEngine         *SdkPlatformFolderInfoEngineVersion `json:"sinceEngine ,omitempty"`
+	SinceEngine         *SdkPlatformFolderInfoEngineVersion `json:"sinceEngine,omitempty"`
 }
 
 type SdkPlatformFolders = map[string][]SdkPlatformFoldersInfo
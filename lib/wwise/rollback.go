@@ -0,0 +1,15 @@
+package wwise
+
+import (
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/pkg/errors"
+)
+
+// Rollback undoes the last integration to, or uninstallation from, the target, restoring the files it changed.
+func Rollback(target IntegrationTarget) error {
+	if err := install.Rollback(target.PluginsDir()); err != nil {
+		return errors.Wrap(err, "failed to roll back integration")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package wwise
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+func sdkVersionForIntegration(versionInfo product.ProductVersionInfo) string {
+	return fmt.Sprintf("%d.%d.%d.%d", versionInfo.Version.Year, versionInfo.Version.Major, versionInfo.Version.Minor, versionInfo.ProductDependentData.WwiseSdkBuild)
+}
+
+func matchesPlatform(deploymentPlatform string, platforms []string) bool {
+	for _, platform := range platforms {
+		if strings.EqualFold(deploymentPlatform, platform) || strings.HasPrefix(strings.ToLower(deploymentPlatform), strings.ToLower(platform)+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// sdkFileFilters selects the SDK files for the given platforms and plugins.
+// Files that are not tied to a deployment platform or to a plugin are always selected.
+func sdkFileFilters(sdkVersionInfo product.ProductVersionInfo, platforms []string, plugins []string) []product.GroupFilter {
+	filters := []product.GroupFilter{
+		{GroupID: "Packages", GroupValues: []string{"SDK"}},
+		{GroupID: "Plugins", GroupValues: append([]string{""}, plugins...)},
+	}
+
+	if len(platforms) > 0 {
+		deploymentPlatforms := []string{""}
+		for _, value := range sdkVersionInfo.GroupValues("DeploymentPlatforms") {
+			if matchesPlatform(value, platforms) {
+				deploymentPlatforms = append(deploymentPlatforms, value)
+			}
+		}
+		filters = append(filters, product.GroupFilter{GroupID: "DeploymentPlatforms", GroupValues: deploymentPlatforms})
+	}
+
+	return filters
+}
+
+func downloadSDKFiles(sdkProductVersion *product.WwiseProductVersion, platforms []string, plugins []string) error {
+	sdkVersionInfo, err := sdkProductVersion.GetInfo()
+	if err != nil {
+		return errors.Wrap(err, "failed to get sdk version info")
+	}
+
+	files := sdkVersionInfo.FindFilesByGroups(sdkFileFilters(sdkVersionInfo, platforms, plugins))
+	if len(files) == 0 {
+		return errors.New("failed to find sdk files")
+	}
+
+	for _, file := range files {
+		err = sdkProductVersion.DownloadOrCache(file)
+		if err != nil {
+			return errors.Wrapf(err, "failed to download sdk file %s", file.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package wwise
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+)
+
+// sdkAssetsFixture is an excerpt of the version.json of an integration, the SDK files extracted
+// for it and the files that are installed to the plugins directory for the selection.
+type sdkAssetsFixture struct {
+	EngineVersion  string                     `json:"engineVersion"`
+	Platforms      []string                   `json:"platforms"`
+	Configurations []string                   `json:"configurations"`
+	VersionInfo    product.ProductVersionInfo `json:"versionInfo"`
+	SdkFiles       []string                   `json:"sdkFiles"`
+	Expected       []string                   `json:"expected"`
+}
+
+func TestSDKAssetFiles(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "sdkplatformfolders", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, fixturePath := range fixtures {
+		t.Run(filepath.Base(fixturePath), func(t *testing.T) {
+			data, err := os.ReadFile(fixturePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fixture sdkAssetsFixture
+			if err := json.Unmarshal(data, &fixture); err != nil {
+				t.Fatal(err)
+			}
+
+			sdkDir := t.TempDir()
+			for _, file := range fixture.SdkFiles {
+				p := filepath.Join(sdkDir, filepath.FromSlash(file))
+				if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(p, []byte(file), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			configurations, err := normalizeConfigurations(fixture.Configurations)
+			if err != nil {
+				t.Fatal(err)
+			}
+			options := IntegrationOptions{Platforms: fixture.Platforms, Configurations: configurations}
+			target := UnrealTarget{EngineVersion: fixture.EngineVersion}
+
+			sdkAssets, err := (&UnrealIntegrator{}).SDKAssets(target, fixture.VersionInfo, sdkDir, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files, err := listSDKAssetFiles(sdkDir, sdkAssets, options.Configurations)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, file := range files {
+				got = append(got, file.Path)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, fixture.Expected) {
+				t.Errorf("installed files:\n%v\nwant:\n%v", got, fixture.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package wwise
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+// UnrealPluginStatus is whether a plugin of the integration is enabled in the project file.
+type UnrealPluginStatus struct {
+	Name string
+	// Listed is false if the project file has no entry for the plugin, so the plugin decides whether it is enabled.
+	Listed  bool
+	Enabled bool
+}
+
+// UnrealStatus describes the Wwise integration of a project or engine, as far as it can be known without the Wwise API.
+type UnrealStatus struct {
+	EngineAssociation string
+	EngineRoot        string
+	EngineVersion     *unrealengine.EngineVersion
+	// EngineError is why the engine or its version could not be found.
+	EngineError string
+
+	// Installed is the install manifest, or the integration detected from Wwise.uplugin. It is nil if there is no integration.
+	Installed *install.Manifest
+	// Managed is whether the integration was installed by wwise-cli.
+	Managed    bool
+	SdkVersion string
+	// ThirdPartyPlatforms are the SDK platform folders in the integration's ThirdParty directory.
+	ThirdPartyPlatforms []string
+	Plugins             []UnrealPluginStatus
+
+	// CachedIntegration and CachedSdk are whether the download cache holds what reinstalling the integration needs.
+	CachedIntegration bool
+	CachedSdk         bool
+}
+
+// readSDKVersionHeader reads the SDK version from AkWwiseSDKVersion.h, for integrations that were not installed by wwise-cli.
+func readSDKVersionHeader(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open SDK version header")
+	}
+	defer file.Close()
+
+	defines := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "#define" || !strings.HasPrefix(fields[1], "AK_WWISESDK_VERSION_") {
+			continue
+		}
+		if number, err := strconv.Atoi(fields[2]); err == nil {
+			defines[strings.TrimPrefix(fields[1], "AK_WWISESDK_VERSION_")] = number
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrap(err, "failed to read SDK version header")
+	}
+
+	for _, name := range []string{"MAJOR", "MINOR", "SUBMINOR", "BUILD"} {
+		if _, ok := defines[name]; !ok {
+			return "", errors.New("failed to find AK_WWISESDK_VERSION_" + name)
+		}
+	}
+	return fmt.Sprintf("%d.%d.%d.%d", defines["MAJOR"], defines["MINOR"], defines["SUBMINOR"], defines["BUILD"]), nil
+}
+
+// GetUnrealStatus inspects the Wwise integration of the target. Everything it reports is read from the disk.
+func GetUnrealStatus(target UnrealTarget) (*UnrealStatus, error) {
+	status := &UnrealStatus{}
+
+	var uproject unrealengine.UProject
+	if !target.IsEngine() {
+		var err error
+		uproject, err = unrealengine.ReadUProject(target.Project)
+		if err != nil {
+			return nil, err
+		}
+		status.EngineAssociation = uproject.EngineAssociation
+	}
+
+	engineRoot, err := target.GetEngineRoot()
+	if err != nil {
+		status.EngineError = err.Error()
+	} else {
+		status.EngineRoot = engineRoot
+		engineVersion, err := target.GetEngineVersion()
+		if err != nil {
+			status.EngineError = err.Error()
+		} else {
+			status.EngineVersion = &engineVersion
+		}
+	}
+
+	pluginsDir := target.PluginsDir()
+	managed, err := install.ReadManifest(pluginsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read install manifest")
+	}
+	status.Managed = managed != nil
+
+	status.Installed, err = installedUnreal(pluginsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if status.Installed == nil {
+		return status, nil
+	}
+
+	status.SdkVersion = status.Installed.SdkVersion
+	thirdPartyDir := filepath.Join(pluginsDir, "Wwise", "ThirdParty")
+	if status.SdkVersion == "" {
+		status.SdkVersion, _ = readSDKVersionHeader(filepath.Join(thirdPartyDir, "include", "AK", "AkWwiseSDKVersion.h"))
+	}
+
+	entries, err := os.ReadDir(thirdPartyDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to list third party directory")
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != "include" {
+			status.ThirdPartyPlatforms = append(status.ThirdPartyPlatforms, entry.Name())
+		}
+	}
+
+	roots := status.Installed.Roots()
+	if len(roots) == 0 {
+		roots = []string{"Wwise"}
+	}
+	for _, root := range roots {
+		uplugins, err := filepath.Glob(filepath.Join(pluginsDir, root, "*.uplugin"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to search for plugin files")
+		}
+		for _, uplugin := range uplugins {
+			plugin := UnrealPluginStatus{Name: strings.TrimSuffix(filepath.Base(uplugin), filepath.Ext(uplugin))}
+			for _, entry := range uproject.Plugins {
+				if strings.EqualFold(entry.Name, plugin.Name) {
+					plugin.Listed = true
+					plugin.Enabled = entry.Enabled
+				}
+			}
+			status.Plugins = append(status.Plugins, plugin)
+		}
+	}
+	sort.Slice(status.Plugins, func(i, j int) bool { return status.Plugins[i].Name < status.Plugins[j].Name })
+
+	if status.EngineVersion != nil {
+		status.CachedIntegration, err = isIntegrationCached(status.Installed.IntegrationVersion, *status.EngineVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if status.SdkVersion != "" {
+		status.CachedSdk, err = isSDKCached(status.SdkVersion, status.Installed.Platforms)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+func isIntegrationCached(integrationVersion string, engineVersion unrealengine.EngineVersion) (bool, error) {
+	integrationProduct := product.NewWwiseProduct(nil, "unrealintegration")
+	if !integrationProduct.IsCached(integrationVersion) {
+		return false, nil
+	}
+	productVersion, err := integrationProduct.GetVersion(integrationVersion)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read cached integration")
+	}
+
+	// Resolve the deployment platform like integrating does, with the manifest of the integration if it was cached
+	var versionInfo *product.ProductVersionInfo
+	if info, err := productVersion.CachedInfo(); err == nil {
+		versionInfo = &info
+	}
+	deploymentPlatform, err := UnrealDeploymentPlatform(engineVersion, versionInfo)
+	if err != nil {
+		return false, nil
+	}
+	return productVersion.IsGroupDownloaded("DeploymentPlatforms", deploymentPlatform), nil
+}
+
+func isSDKCached(sdkVersion string, platforms []string) (bool, error) {
+	sdkProduct := product.NewWwiseProduct(nil, "wwise")
+	if !sdkProduct.IsCached(sdkVersion) {
+		return false, nil
+	}
+	productVersion, err := sdkProduct.GetVersion(sdkVersion)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read cached sdk")
+	}
+
+	if !productVersion.IsGroupDownloaded("Packages", "SDK") {
+		return false, nil
+	}
+	downloaded := productVersion.DownloadedGroupValues("DeploymentPlatforms")
+	for _, platform := range platforms {
+		found := false
+		for _, value := range downloaded {
+			if matchesPlatform(value, []string{platform}) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
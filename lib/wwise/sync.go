@@ -0,0 +1,142 @@
+package wwise
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/manifest"
+	"github.com/mircearoata/wwise-cli/lib/wwise/client"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+// sameSet compares two selections of platforms, configurations or plugins, ignoring order and case.
+func sameSet(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := make([]string, len(a))
+	sortedB := make([]string, len(b))
+	for i := range a {
+		sortedA[i] = strings.ToLower(a[i])
+		sortedB[i] = strings.ToLower(b[i])
+	}
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// SettingWwiseProject is the setting the Wwise project declared by a manifest is passed to its integrator as.
+// Only the integrators having the setting can sync manifests declaring a Wwise project.
+const SettingWwiseProject = "wwise-project"
+
+func isUpToDate(m *manifest.Manifest, integrator Integrator, installed *install.Manifest, pluginsDir string) bool {
+	if installed == nil {
+		return false
+	}
+	roots := installed.Roots()
+	if len(roots) == 0 {
+		return false
+	}
+	for _, root := range roots {
+		if _, err := os.Stat(filepath.Join(pluginsDir, root)); err != nil {
+			return false
+		}
+	}
+	if installed.IntegrationVersion != strings.TrimPrefix(m.IntegrationVersion, integrator.Product()+".") {
+		return false
+	}
+	if m.SdkVersion != "" && installed.SdkVersion != strings.TrimPrefix(m.SdkVersion, "wwise.") {
+		return false
+	}
+	return sameSet(installed.Platforms, m.Platforms) && sameSet(installed.Configurations, m.Configurations) && sameSet(installed.Plugins, m.Plugins)
+}
+
+// Sync brings the project declared by the manifest to the state the manifest describes, with the integrator of its engine
+// and the target settings. It returns true without downloading or copying anything if the project is already up to date.
+func Sync(m *manifest.Manifest, targetSettings map[string]string, wwiseClient *client.WwiseClient) (bool, *install.Result, error) {
+	integrator, ok := LookupIntegrator(m.EngineName())
+	if !ok {
+		return false, nil, errors.Errorf("unknown engine %s, available engines: %s", m.EngineName(), strings.Join(IntegratorNames(), ", "))
+	}
+
+	settings := make(map[string]string)
+	for name, value := range targetSettings {
+		settings[name] = value
+	}
+	if m.WwiseProject != "" {
+		hasSetting := false
+		for _, setting := range integrator.Settings() {
+			hasSetting = hasSetting || setting.Name == SettingWwiseProject
+		}
+		if !hasSetting {
+			return false, nil, errors.Errorf("the %s integration does not configure a Wwise project, remove wwiseProject from the manifest", integrator.Name())
+		}
+		settings[SettingWwiseProject] = m.WwiseProjectFile()
+	}
+
+	target, err := integrator.DetectProject(m.ProjectPath(), settings)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to detect project")
+	}
+	pluginsDir := target.PluginsDir()
+
+	installed, err := install.ReadManifest(pluginsDir)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to read install manifest")
+	}
+
+	upToDate := isUpToDate(m, integrator, installed, pluginsDir)
+	if checker, ok := integrator.(SettingsChecker); ok && upToDate {
+		// Settings that are not set anymore are written by integrating again, with the edits of the installation
+		upToDate, err = checker.SettingsUpToDate(target, settings)
+		if err != nil {
+			return false, nil, err
+		}
+	}
+	if upToDate {
+		return true, nil, nil
+	}
+
+	// Keep the files linked the way they are, linking is a choice of each checkout rather than of the manifest
+	var link install.LinkMode
+	if installed != nil {
+		link = installed.Link
+	}
+
+	integrationVersion, err := product.NewWwiseProduct(wwiseClient, integrator.Product()).GetVersion(m.IntegrationVersion)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to get integration version")
+	}
+
+	versionInfo, err := integrationVersion.GetInfo()
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to get wwise manifest")
+	}
+
+	wwiseSDKVersion := sdkVersionForIntegration(versionInfo)
+	if m.SdkVersion != "" && strings.TrimPrefix(m.SdkVersion, "wwise.") != wwiseSDKVersion {
+		return false, nil, errors.Errorf("integration %s requires sdk %s, but the manifest declares %s", integrationVersion.VersionId, wwiseSDKVersion, m.SdkVersion)
+	}
+
+	result, err := InstallIntegration(integrator, target, integrationVersion.VersionId, IntegrationOptions{
+		Platforms:      m.Platforms,
+		Configurations: m.Configurations,
+		Plugins:        m.Plugins,
+		Link:           link,
+		Settings:       settings,
+	}, wwiseClient)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to integrate wwise")
+	}
+
+	return false, result, nil
+}
@@ -0,0 +1,75 @@
+package wwise
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/manifest"
+	"github.com/spf13/viper"
+)
+
+// unityProject creates a Unity project with a wwise-cli installation in Assets, and a manifest next to it.
+func unityProject(t *testing.T, manifestContent string) *manifest.Manifest {
+	t.Helper()
+	viper.Set("cache-dir", t.TempDir())
+
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, "ProjectSettings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "ProjectSettings", "ProjectVersion.txt"), []byte("m_EditorVersion: 2022.3.10f1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assetsDir := filepath.Join(projectDir, "Assets")
+	if err := os.MkdirAll(filepath.Join(assetsDir, "Wwise"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	installed := install.Manifest{IntegrationVersion: "2023.1.0.8367", Files: map[string]string{"Wwise/AkSoundEngine.cs": "hash"}}
+	if err := installed.Save(assetsDir); err != nil {
+		t.Fatal(err)
+	}
+
+	manifestPath := filepath.Join(projectDir, manifest.DefaultFileName)
+	if err := os.WriteFile(manifestPath, []byte(manifestContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSyncWithTheIntegratorOfTheManifest(t *testing.T) {
+	m := unityProject(t, "engine: unity\nintegrationVersion: unityintegration.2023.1.0.8367\n")
+
+	// Up to date, so nothing is downloaded
+	upToDate, _, err := Sync(m, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !upToDate {
+		t.Error("Unity project not up to date with the installed version")
+	}
+}
+
+func TestSyncWwiseProjectWithoutSetting(t *testing.T) {
+	m := unityProject(t, "engine: unity\nintegrationVersion: 2023.1.0.8367\nwwiseProject: Game.wproj\n")
+
+	_, _, err := Sync(m, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "does not configure a Wwise project") {
+		t.Errorf("got error %v, want one saying unity does not configure a Wwise project", err)
+	}
+}
+
+func TestSyncUnknownEngine(t *testing.T) {
+	m := unityProject(t, "engine: godot\nintegrationVersion: 2023.1.0.8367\n")
+
+	_, _, err := Sync(m, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "unknown engine godot") {
+		t.Errorf("got error %v, want one naming the unknown engine", err)
+	}
+}
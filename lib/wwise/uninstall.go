@@ -0,0 +1,111 @@
+package wwise
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/pkg/errors"
+)
+
+// unrealEditChanges are the changes an edit of the Unreal integration makes, recorded in the install manifest
+// so that uninstalling reverts them.
+type unrealEditChanges struct {
+	Plugins []unrealengine.ProjectPluginChange `json:"plugins,omitempty"`
+	Config  []unrealengine.ConfigChange        `json:"config,omitempty"`
+}
+
+// recordedUnrealChanges returns the changes of the edits recorded in the install manifest, oldest first.
+func recordedUnrealChanges(installed *install.Manifest) ([]unrealengine.ProjectPluginChange, []unrealengine.ConfigChange, error) {
+	var pluginChanges []unrealengine.ProjectPluginChange
+	var configChanges []unrealengine.ConfigChange
+	for _, record := range installed.Edits {
+		var changes unrealEditChanges
+		if err := json.Unmarshal(record.Changes, &changes); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to unmarshal changes of %s", record.Path)
+		}
+		pluginChanges = append(pluginChanges, changes.Plugins...)
+		configChanges = append(configChanges, changes.Config...)
+	}
+	return pluginChanges, configChanges, nil
+}
+
+// unrealRevertEdits returns the edits of the project files that revert the changes recorded in the install manifest.
+// Integrations that were not installed by wwise-cli have no recorded changes, so their project files are left as they are.
+func unrealRevertEdits(target UnrealTarget, installed *install.Manifest) ([]install.FileEdit, error) {
+	pluginChanges, configChanges, err := recordedUnrealChanges(installed)
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []install.FileEdit
+	if len(pluginChanges) > 0 {
+		content, changed, err := unrealengine.RevertProjectPlugins(target.Project, pluginChanges)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to remove plugins from project")
+		}
+		if changed {
+			edit, err := install.NewFileEdit(target.Project, content, "revert plugin changes")
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to plan project file edit")
+			}
+			edits = append(edits, edit)
+		}
+	}
+
+	changed, err := unrealengine.RevertConfigSettings(filepath.Dir(target.Project), configChanges)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to revert Wwise settings")
+	}
+	paths := make([]string, 0, len(changed))
+	for configPath := range changed {
+		paths = append(paths, configPath)
+	}
+	sort.Strings(paths)
+	for _, configPath := range paths {
+		edit, err := install.NewFileEdit(configPath, changed[configPath], "revert Wwise settings")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to plan config file edit")
+		}
+		edits = append(edits, edit)
+	}
+
+	return edits, nil
+}
+
+// uninstallUnreal removes the Wwise integration from the target. For projects, it also reverts the changes
+// recorded when integrating to the project file and the project's config files, and nothing else.
+func uninstallUnreal(target UnrealTarget, force bool) (*install.UninstallResult, error) {
+	pluginsDir := target.PluginsDir()
+
+	installed, err := installedUnreal(pluginsDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if installed == nil {
+		return nil, errors.New("failed to find a Wwise integration in " + pluginsDir)
+	}
+
+	var edits []install.FileEdit
+	if !target.IsEngine() {
+		edits, err = unrealRevertEdits(target, installed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := install.Uninstall(pluginsDir, installed, edits, force)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove integration")
+	}
+
+	if !target.IsEngine() {
+		// Remove the plugins directory if the integration was all it had
+		_ = os.Remove(pluginsDir)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,343 @@
+package wwise
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/wwise/client"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/pkg/errors"
+)
+
+// UnityProject is a Unity project directory. The integration is installed either as embedded packages in
+// Packages, for integrations distributed as Unity packages, or in Assets for older integrations.
+type UnityProject struct {
+	Dir string
+}
+
+func NewUnityProject(dir string) (UnityProject, error) {
+	if _, err := os.Stat(filepath.Join(dir, "ProjectSettings", "ProjectVersion.txt")); err != nil {
+		if os.IsNotExist(err) {
+			return UnityProject{}, errors.New(dir + " is not a Unity project, ProjectSettings/ProjectVersion.txt is missing")
+		}
+		return UnityProject{}, errors.Wrap(err, "failed to read Unity project")
+	}
+	return UnityProject{Dir: dir}, nil
+}
+
+// EditorVersion returns the Unity editor version the project was last opened with.
+func (p UnityProject) EditorVersion() (string, error) {
+	file, err := os.Open(filepath.Join(p.Dir, "ProjectSettings", "ProjectVersion.txt"))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to open project version")
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if ok && strings.TrimSpace(key) == "m_EditorVersion" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", errors.Wrap(err, "failed to read project version")
+	}
+	return "", errors.New("failed to find m_EditorVersion in the project version")
+}
+
+// unityLayouts are the directories of an extracted integration that can hold what is installed, in the order they are
+// looked for: Packages for integrations distributed as Unity packages, Assets for older ones. Each is installed to the
+// project directory of the same name.
+var unityLayouts = []string{"Packages", "Assets"}
+
+// PluginsDir returns the directory the integration is installed to. Without an installation, it is the one the last
+// installation or uninstallation can be rolled back in, or else Packages, where current integrations are installed.
+func (p UnityProject) PluginsDir() string {
+	if dir, installed, err := InstalledUnity(p); err == nil && installed != nil {
+		return dir
+	}
+	for _, dir := range p.InstallDirs() {
+		if install.HasBackup(dir) {
+			return dir
+		}
+	}
+	return filepath.Join(p.Dir, unityLayouts[0])
+}
+
+// InstallDirs returns the directories the integration can be installed to, in the order they are searched for an installation.
+func (p UnityProject) InstallDirs() []string {
+	var dirs []string
+	for _, layoutDir := range unityLayouts {
+		dirs = append(dirs, filepath.Join(p.Dir, layoutDir))
+	}
+	return dirs
+}
+
+// InstalledUnity returns the directory of the Wwise integration of the project and its install manifest, or nil if
+// there is none. An integration imported without wwise-cli, in Assets/Wwise, has a manifest without files or version.
+func InstalledUnity(project UnityProject) (string, *install.Manifest, error) {
+	for _, dir := range project.InstallDirs() {
+		installed, err := install.ReadManifest(dir)
+		if err != nil {
+			return "", nil, err
+		}
+		if installed != nil {
+			return dir, installed, nil
+		}
+	}
+
+	assetsDir := filepath.Join(project.Dir, "Assets")
+	if info, err := os.Stat(filepath.Join(assetsDir, "Wwise")); err == nil && info.IsDir() {
+		return assetsDir, &install.Manifest{IntegrationVersion: "unknown"}, nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return "", nil, errors.Wrap(err, "failed to read Wwise assets")
+	}
+	return "", nil, nil
+}
+
+// unityIntegrationLayout returns the directory of the extracted integration holding what is installed,
+// and the project directory it is installed to.
+func unityIntegrationLayout(cacheDir string, project UnityProject) (string, string) {
+	for _, layoutDir := range unityLayouts {
+		if info, err := os.Stat(filepath.Join(cacheDir, layoutDir)); err == nil && info.IsDir() {
+			return filepath.Join(cacheDir, layoutDir), filepath.Join(project.Dir, layoutDir)
+		}
+	}
+	return cacheDir, filepath.Join(project.Dir, "Assets")
+}
+
+// UnityIntegrator integrates Wwise to Unity projects. The integration ships its own native plugins,
+// so the SDK version it was built with is only recorded.
+type UnityIntegrator struct{}
+
+func init() {
+	RegisterIntegrator(&UnityIntegrator{})
+}
+
+func asUnityProject(target IntegrationTarget) (UnityProject, error) {
+	project, ok := target.(UnityProject)
+	if !ok {
+		return UnityProject{}, errors.New("not a Unity project")
+	}
+	return project, nil
+}
+
+func (i *UnityIntegrator) Name() string {
+	return "unity"
+}
+
+func (i *UnityIntegrator) Product() string {
+	return "unityintegration"
+}
+
+func (i *UnityIntegrator) SDKProduct() string {
+	return ""
+}
+
+func (i *UnityIntegrator) Settings() []IntegrationSetting {
+	return nil
+}
+
+func (i *UnityIntegrator) DetectProject(projectPath string, settings map[string]string) (IntegrationTarget, error) {
+	if projectPath == "" {
+		return nil, errors.New("no project set")
+	}
+	return NewUnityProject(projectPath)
+}
+
+func (i *UnityIntegrator) EngineVersion(target IntegrationTarget) (string, error) {
+	project, err := asUnityProject(target)
+	if err != nil {
+		return "", err
+	}
+	return project.EditorVersion()
+}
+
+// NormalizeOptions checks the configurations, which select the build configurations of the native plugins.
+func (i *UnityIntegrator) NormalizeOptions(options IntegrationOptions) (IntegrationOptions, error) {
+	configurations, err := normalizeConfigurations(options.Configurations)
+	if err != nil {
+		return IntegrationOptions{}, err
+	}
+	options.Configurations = configurations
+	return options, nil
+}
+
+// unityDeploymentPlatforms returns the deployment platforms of the integration files to download for the selected platforms,
+// which are prefixes of the integration's deployment platforms. The files of no deployment platform are always downloaded.
+func unityDeploymentPlatforms(versionInfo product.ProductVersionInfo, platforms []string) ([]string, error) {
+	availablePlatforms := versionInfo.GroupValues("DeploymentPlatforms")
+	deploymentPlatforms := []string{""}
+	for _, platform := range availablePlatforms {
+		if len(platforms) == 0 || matchesPlatform(platform, platforms) {
+			deploymentPlatforms = append(deploymentPlatforms, platform)
+		}
+	}
+	for _, platform := range platforms {
+		if len(availablePlatforms) > 0 && !matchesPlatform(platform, availablePlatforms) && !hasPlatformWithPrefix(availablePlatforms, platform) {
+			sort.Strings(availablePlatforms)
+			return nil, errors.Errorf("unknown platform %s, available platforms: %s", platform, strings.Join(availablePlatforms, ", "))
+		}
+	}
+	return deploymentPlatforms, nil
+}
+
+func (i *UnityIntegrator) IntegrationFiles(target IntegrationTarget, versionInfo product.ProductVersionInfo, options IntegrationOptions) ([]product.File, error) {
+	deploymentPlatforms, err := unityDeploymentPlatforms(versionInfo, options.Platforms)
+	if err != nil {
+		return nil, err
+	}
+
+	integrationFiles := versionInfo.FindFilesByGroups([]product.GroupFilter{
+		{GroupID: "Packages", GroupValues: []string{"Unity"}},
+		{GroupID: "DeploymentPlatforms", GroupValues: deploymentPlatforms},
+	})
+	if len(integrationFiles) == 0 {
+		return nil, errors.New("failed to find integration files")
+	}
+	return integrationFiles, nil
+}
+
+// InstallFiles installs the packages of the integration to Packages, or its assets to Assets for older integrations.
+// The native plugins of the platforms and configurations that were not selected are left out.
+func (i *UnityIntegrator) InstallFiles(target IntegrationTarget, versionInfo product.ProductVersionInfo, integrationDir string, options IntegrationOptions) (string, []install.File, error) {
+	project, err := asUnityProject(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sourceDir, installDir := unityIntegrationLayout(integrationDir, project)
+
+	installedDir, installed, err := InstalledUnity(project)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if installed != nil && installedDir != installDir {
+		return "", nil, errors.Errorf("the installed integration is in %s, but this integration version installs to %s, uninstall it first", installedDir, installDir)
+	}
+
+	entries, err := os.ReadDir(sourceDir)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to read integration cache path")
+	}
+
+	// The cache can hold platforms downloaded earlier, leave out the native plugins of the ones that were not selected
+	availablePlatforms := versionInfo.GroupValues("DeploymentPlatforms")
+	skipDir := func(relPath string) bool {
+		name := path.Base(relPath)
+		if path.Base(path.Dir(relPath)) == "Plugins" && len(options.Platforms) > 0 && hasPlatformWithPrefix(availablePlatforms, name) && !matchesPlatform(name, options.Platforms) {
+			return true
+		}
+		return len(options.Configurations) > 0 && !isConfigurationSelected(name, options.Configurations)
+	}
+
+	var files []install.File
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			// The cache directory itself also holds what was downloaded
+			if sourceDir == integrationDir && (entry.Name() == "info.json" || entry.Name() == "version.json") {
+				continue
+			}
+			// Top level files are the .meta files of the top level folders
+			files = append(files, install.File{
+				Path:      entry.Name(),
+				Source:    filepath.Join(sourceDir, entry.Name()),
+				Mergeable: true,
+			})
+			continue
+		}
+		entryDir := filepath.Join(sourceDir, entry.Name())
+		assetFiles, err := listFiles(entryDir, entry.Name(), func(relPath string, d fs.DirEntry) bool {
+			if d.IsDir() {
+				return skipDir(relPath)
+			}
+			// Unity keeps a .meta file next to every folder, which goes with the folder
+			dirPath := strings.TrimSuffix(relPath, ".meta")
+			if dirPath == relPath {
+				return false
+			}
+			if info, err := os.Stat(filepath.Join(entryDir, filepath.FromSlash(dirPath))); err != nil || !info.IsDir() {
+				return false
+			}
+			return skipDir(dirPath)
+		})
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to list integration asset: %s", entry.Name())
+		}
+		// Projects often carry patches on the integration scripts, keep them mergeable across upgrades
+		for n := range assetFiles {
+			assetFiles[n].Mergeable = true
+		}
+		files = append(files, assetFiles...)
+	}
+
+	return installDir, files, nil
+}
+
+func (i *UnityIntegrator) SDKPlatforms(target IntegrationTarget, versionInfo product.ProductVersionInfo, options IntegrationOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (i *UnityIntegrator) SDKAssets(target IntegrationTarget, versionInfo product.ProductVersionInfo, sdkDir string, options IntegrationOptions) ([]SDKAsset, error) {
+	return nil, nil
+}
+
+// Edits returns no edits, Unity picks up the packages and assets by itself.
+func (i *UnityIntegrator) Edits(target IntegrationTarget, versionInfo product.ProductVersionInfo, files []install.File, options IntegrationOptions) ([]install.FileEdit, error) {
+	return nil, nil
+}
+
+func (i *UnityIntegrator) Installed(target IntegrationTarget) (*install.Manifest, error) {
+	project, err := asUnityProject(target)
+	if err != nil {
+		return nil, err
+	}
+	_, installed, err := InstalledUnity(project)
+	return installed, err
+}
+
+func (i *UnityIntegrator) Verify(target IntegrationTarget, integrationVersion string, wwiseClient *client.WwiseClient) (*install.Verification, error) {
+	return VerifyIntegration(i, target, integrationVersion, wwiseClient)
+}
+
+// Uninstall removes the files installed by wwise-cli. Integrations imported without it are left to the user.
+func (i *UnityIntegrator) Uninstall(target IntegrationTarget, force bool) (*install.UninstallResult, error) {
+	project, err := asUnityProject(target)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, installed, err := InstalledUnity(project)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect installed integration")
+	}
+	if installed == nil {
+		return nil, errors.New("failed to find a Wwise integration in " + project.Dir)
+	}
+	managed, err := install.ReadManifest(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read install manifest")
+	}
+	if managed == nil {
+		return nil, errors.New("the integration in " + filepath.Join(dir, "Wwise") + " was not installed by wwise-cli, remove it from the Unity editor")
+	}
+
+	return install.Uninstall(dir, managed, nil, force)
+}
+
+// hasPlatformWithPrefix returns whether one of the deployment platforms is, or starts with, the platform.
+func hasPlatformWithPrefix(deploymentPlatforms []string, platform string) bool {
+	for _, deploymentPlatform := range deploymentPlatforms {
+		if matchesPlatform(deploymentPlatform, []string{platform}) {
+			return true
+		}
+	}
+	return false
+}
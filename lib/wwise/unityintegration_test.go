@@ -0,0 +1,94 @@
+package wwise
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mircearoata/wwise-cli/lib/install"
+	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/spf13/viper"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUnityPluginsDirFollowsTheLayout(t *testing.T) {
+	viper.Set("cache-dir", t.TempDir())
+	projectDir := t.TempDir()
+	writeTestFiles(t, projectDir, map[string]string{"ProjectSettings/ProjectVersion.txt": "m_EditorVersion: 2022.3.10f1\n"})
+	project, err := NewUnityProject(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packagesDir := filepath.Join(projectDir, "Packages")
+
+	// An integration distributed as Unity packages
+	integrationDir := t.TempDir()
+	writeTestFiles(t, integrationDir, map[string]string{
+		"info.json": "{}",
+		"Packages/com.audiokinetic.wwise.core/package.json":            "{}",
+		"Packages/com.audiokinetic.wwise.core/Runtime/AkUnity.cs":      "class AkUnity {}",
+		"Packages/com.audiokinetic.wwise.core/Runtime/AkUnity.cs.meta": "guid: 1",
+	})
+
+	if dir := project.PluginsDir(); dir != packagesDir {
+		t.Errorf("plugins directory before installing %s, want %s", dir, packagesDir)
+	}
+	integrator := &UnityIntegrator{}
+	installDir, files, err := integrator.InstallFiles(project, product.ProductVersionInfo{}, integrationDir, IntegrationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installDir != packagesDir {
+		t.Errorf("installing to %s, want %s", installDir, packagesDir)
+	}
+	plan, err := install.NewPlan(installDir, files, install.Manifest{IntegrationVersion: "2023.1.0.8367"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if dir := project.PluginsDir(); dir != packagesDir {
+		t.Errorf("plugins directory after installing %s, want %s", dir, packagesDir)
+	}
+
+	// The uninstallation can be rolled back without the install manifest
+	if _, err := integrator.Uninstall(project, false); err != nil {
+		t.Fatal(err)
+	}
+	if dir := project.PluginsDir(); dir != packagesDir {
+		t.Errorf("plugins directory after uninstalling %s, want %s", dir, packagesDir)
+	}
+	if err := Rollback(project); err != nil {
+		t.Fatal(err)
+	}
+	if installed, err := integrator.Installed(project); err != nil || installed == nil || installed.IntegrationVersion != "2023.1.0.8367" {
+		t.Errorf("installed %v after rolling back the uninstallation, %v", installed, err)
+	}
+}
+
+func TestUnityInstallFilesToAnotherLayout(t *testing.T) {
+	m := unityProject(t, "engine: unity\nintegrationVersion: 2023.1.0.8367\n")
+	project, err := NewUnityProject(m.ProjectPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	integrationDir := t.TempDir()
+	writeTestFiles(t, integrationDir, map[string]string{"Packages/com.audiokinetic.wwise.core/package.json": "{}"})
+	if _, _, err := (&UnityIntegrator{}).InstallFiles(project, product.ProductVersionInfo{}, integrationDir, IntegrationOptions{}); err == nil {
+		t.Error("installed to Packages over an integration installed to Assets")
+	}
+}
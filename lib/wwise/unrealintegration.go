@@ -1,52 +1,407 @@
 package wwise
 
 import (
-	"fmt"
+	"encoding/json"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/mircearoata/wwise-cli/lib/install"
 	"github.com/mircearoata/wwise-cli/lib/unrealengine"
 	"github.com/mircearoata/wwise-cli/lib/wwise/client"
 	"github.com/mircearoata/wwise-cli/lib/wwise/product"
+	"github.com/mircearoata/wwise-cli/utils"
 	"github.com/pkg/errors"
+)
+
+var sdkConfigurations = []string{"Debug", "Profile", "Release"}
+
+// normalizeConfigurations validates the selected configurations and returns them with the SDK folder casing.
+func normalizeConfigurations(selected []string) ([]string, error) {
+	configurations := make([]string, 0, len(selected))
+	for _, configuration := range selected {
+		found := false
+		for _, sdkConfiguration := range sdkConfigurations {
+			if strings.EqualFold(configuration, sdkConfiguration) {
+				configurations = append(configurations, sdkConfiguration)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown configuration %s, available configurations: %s", configuration, strings.Join(sdkConfigurations, ", "))
+		}
+	}
+	return configurations, nil
+}
+
+func validatePlatforms(productDependentData product.ProductDependentData, selected []string) error {
+	if productDependentData.SdkPlatformFolders == nil {
+		return nil
+	}
+
+	var available []string
+	for platform := range *productDependentData.SdkPlatformFolders {
+		available = append(available, platform)
+	}
+	sort.Strings(available)
+
+	for _, platform := range selected {
+		if !isPlatformSelected(platform, available) {
+			return errors.Errorf("unknown platform %s, available platforms: %s", platform, strings.Join(available, ", "))
+		}
+	}
+	return nil
+}
+
+func isPlatformSelected(platform string, selected []string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, selectedPlatform := range selected {
+		if strings.EqualFold(platform, selectedPlatform) {
+			return true
+		}
+	}
+	return false
+}
+
+func isConfigurationSelected(folderName string, configurations []string) bool {
+	for _, sdkConfiguration := range sdkConfigurations {
+		if !strings.EqualFold(folderName, sdkConfiguration) {
+			continue
+		}
+		for _, configuration := range configurations {
+			if configuration == sdkConfiguration {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// listFiles lists the files under sourceDir, installed to destDir (slash separated, relative to the plugins directory).
+// The skip function can leave out files and whole directories, by their slash separated path relative to sourceDir.
+func listFiles(sourceDir string, destDir string, skip func(relPath string, d fs.DirEntry) bool) ([]install.File, error) {
+	var files []install.File
+	err := filepath.WalkDir(sourceDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == sourceDir {
+			return nil
+		}
+		relPath, err := filepath.Rel(sourceDir, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if skip != nil && skip(relPath, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, install.File{
+				Path:   path.Join(destDir, relPath),
+				Source: p,
+			})
+		}
+		return nil
+	})
+	return files, err
+}
+
+// sdkAssetFiles lists the files of an SDK platform folder that match its file expression,
+// leaving out the build configuration folders directly inside it that were not selected.
+func sdkAssetFiles(sourceDir string, destDir string, fileMatch *utils.Glob, configurations []string) ([]install.File, error) {
+	return listFiles(sourceDir, destDir, func(relPath string, d fs.DirEntry) bool {
+		if !d.IsDir() {
+			return !fileMatch.Match(relPath)
+		}
+		return len(configurations) > 0 && !strings.Contains(relPath, "/") && !isConfigurationSelected(d.Name(), configurations)
+	})
+}
+
+func appliesToEngine(platformInfo product.SdkPlatformFoldersInfo, engineVersion unrealengine.EngineVersion) (bool, error) {
+	if platformInfo.SinceEngine != nil {
+		major, err := strconv.Atoi(platformInfo.SinceEngine.Major)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse major version")
+		}
+		minor, err := strconv.Atoi(platformInfo.SinceEngine.Minor)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse minor version")
+		}
+		if engineVersion.Major < major || (engineVersion.Major == major && engineVersion.Minor < minor) {
+			return false, nil
+		}
+	}
+
+	if platformInfo.UntilEngine != nil {
+		major, err := strconv.Atoi(platformInfo.UntilEngine.Major)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse major version")
+		}
+		minor, err := strconv.Atoi(platformInfo.UntilEngine.Minor)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse minor version")
+		}
+		if engineVersion.Major > major || (engineVersion.Major == major && engineVersion.Minor > minor) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// sdkDownloadPlatforms returns the platforms whose SDK files have to be downloaded for the integration:
+// the selected platforms, plus the platforms of any mandatory SDK platform folder.
+// It returns nil, meaning every platform, if no platform was selected.
+func sdkDownloadPlatforms(productDependentData product.ProductDependentData, engineVersion unrealengine.EngineVersion, selected []string) ([]string, error) {
+	if len(selected) == 0 {
+		return nil, nil
+	}
+
+	platforms := append([]string{}, selected...)
+	if productDependentData.SdkPlatformFolders == nil {
+		return platforms, nil
+	}
 
-	cp "github.com/otiai10/copy"
+	for platform, platformInfos := range *productDependentData.SdkPlatformFolders {
+		for _, platformInfo := range platformInfos {
+			if platformInfo.Optional {
+				continue
+			}
+			applies, err := appliesToEngine(platformInfo, engineVersion)
+			if err != nil {
+				return nil, err
+			}
+			if applies {
+				platforms = append(platforms, platform)
+				break
+			}
+		}
+	}
+
+	return platforms, nil
+}
+
+// installedUnreal returns the install manifest of the Wwise integration in pluginsDir, or nil if there is none.
+// Integrations installed without wwise-cli are detected from Wwise.uplugin, and own everything in Wwise/ThirdParty.
+// Before upgrading them, Adopt adds the files of their integration version.
+func installedUnreal(pluginsDir string) (*install.Manifest, error) {
+	installed, err := install.ReadManifest(pluginsDir)
+	if err != nil || installed != nil {
+		return installed, err
+	}
+
+	uplugin, err := unrealengine.ReadUPlugin(filepath.Join(pluginsDir, "Wwise", "Wwise.uplugin"))
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read Wwise plugin")
+	}
+
+	thirdPartyFiles, err := listFiles(filepath.Join(pluginsDir, "Wwise", "ThirdParty"), "Wwise/ThirdParty", nil)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to list third party files")
+	}
+
+	installed = &install.Manifest{
+		IntegrationVersion: uplugin.VersionName,
+		Files:              make(map[string]string),
+	}
+	for _, file := range thirdPartyFiles {
+		hash, err := install.HashFile(file.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to hash %s", file.Source)
+		}
+		installed.Files[file.Path] = hash
+	}
+
+	return installed, nil
+}
+
+// Settings of the Unreal integrator.
+const (
+	unrealSettingTarget        = "target"
+	unrealSettingEngineRoot    = "engine-root"
+	unrealSettingPluginsDir    = "plugins-dir"
+	unrealSettingEngineVersion = "engine-version"
+	// unrealSettingPluginPlatforms limits the platforms the Wwise plugins are enabled for in the project file, as UE platform names.
+	unrealSettingPluginPlatforms = "plugin-platforms"
+	// unrealSettingWwiseProject is the .wproj whose settings are written to the project's config files.
+	unrealSettingWwiseProject  = SettingWwiseProject
+	unrealSettingSoundBanksDir = "soundbanks-dir"
 )
 
-func IntegrateWwiseUnreal(uprojectFilePath string, integrationVersion string, wwiseClient *client.WwiseClient) error {
-	if filepath.Ext(uprojectFilePath) != ".uproject" {
-		return errors.New("invalid project path: " + uprojectFilePath)
+const (
+	unrealTargetProject = "project"
+	unrealTargetEngine  = "engine"
+)
+
+// UnrealIntegrator integrates Wwise to Unreal Engine projects and engines. Its project file settings only apply to project targets.
+type UnrealIntegrator struct{}
+
+func init() {
+	RegisterIntegrator(&UnrealIntegrator{})
+}
+
+func asUnrealTarget(target IntegrationTarget) (UnrealTarget, error) {
+	unrealTarget, ok := target.(UnrealTarget)
+	if !ok {
+		return UnrealTarget{}, errors.New("not an Unreal Engine target")
 	}
+	return unrealTarget, nil
+}
+
+func (i *UnrealIntegrator) Name() string {
+	return "unreal"
+}
 
-	if _, err := os.Stat(uprojectFilePath); os.IsNotExist(err) {
-		return errors.Wrap(err, "project path does not exist")
+func (i *UnrealIntegrator) Product() string {
+	return "unrealintegration"
+}
+
+func (i *UnrealIntegrator) SDKProduct() string {
+	return "wwise"
+}
+
+func (i *UnrealIntegrator) Settings() []IntegrationSetting {
+	return []IntegrationSetting{
+		{Name: unrealSettingTarget, Default: unrealTargetProject, Usage: "Where to install the integration: project, or engine to install it as an engine plugin", Target: true},
+		{Name: unrealSettingEngineRoot, Usage: "Engine to install to with --target engine, or to use instead of the one the project is associated with", Target: true},
+		{Name: unrealSettingPluginsDir, Usage: "Plugins directory, relative to the engine root, to use with --target engine (default Engine/Plugins/Marketplace)", Target: true},
+		{Name: unrealSettingEngineVersion, Usage: "Engine version to integrate for, as major.minor, instead of the version read from the engine", Target: true},
+		{Name: unrealSettingPluginPlatforms, Usage: "UE platforms to enable the Wwise plugins for in the project file, e.g. Win64,Android (defaults to leaving them as they are)"},
+		{Name: unrealSettingWwiseProject, Usage: "Wwise project (.wproj) to configure in the project's config files"},
+		{Name: unrealSettingSoundBanksDir, Usage: "Generated sound banks directory to configure with --wwise-project (default GeneratedSoundBanks next to the .wproj)"},
 	}
+}
+
+func (i *UnrealIntegrator) DetectProject(projectPath string, settings map[string]string) (IntegrationTarget, error) {
+	return DetectUnrealTarget(projectPath, settings)
+}
 
-	ueIntegrationProduct := product.NewWwiseProduct(wwiseClient, "unrealintegration")
+// DetectUnrealTarget returns the target for a project path and the target settings of the Unreal integrator.
+// The project path is a .uproject, a directory holding a single .uproject, or an engine root.
+// The engine target installs to the engine of the engine-root setting instead, and needs no project path.
+func DetectUnrealTarget(projectPath string, settings map[string]string) (UnrealTarget, error) {
+	var target UnrealTarget
+	var err error
+	switch targetType := settings[unrealSettingTarget]; targetType {
+	case "", unrealTargetProject:
+		if projectPath == "" {
+			return UnrealTarget{}, errors.New("no project set, set the target to engine to install to an engine")
+		}
+		target, err = detectUnrealProject(projectPath, settings[unrealSettingPluginsDir])
+		if err != nil {
+			return UnrealTarget{}, err
+		}
+		if !target.IsEngine() {
+			target.EngineRoot = settings[unrealSettingEngineRoot]
+		}
+	case unrealTargetEngine:
+		engineRoot := settings[unrealSettingEngineRoot]
+		if engineRoot == "" {
+			return UnrealTarget{}, errors.New("no engine root set, which is required to install to an engine")
+		}
+		target, err = NewEngineTarget(engineRoot, settings[unrealSettingPluginsDir])
+		if err != nil {
+			return UnrealTarget{}, err
+		}
+	default:
+		return UnrealTarget{}, errors.Errorf("unknown target %s, expected %s or %s", targetType, unrealTargetProject, unrealTargetEngine)
+	}
 
-	ueIntegrationVersion, err := ueIntegrationProduct.GetVersion(integrationVersion)
+	target.EngineVersion = settings[unrealSettingEngineVersion]
+	return target, nil
+}
+
+// detectUnrealProject accepts a .uproject, a directory holding a single .uproject, or an engine root, installed to pluginsDir.
+func detectUnrealProject(projectPath string, pluginsDir string) (UnrealTarget, error) {
+	if filepath.Ext(projectPath) == ".uproject" {
+		return NewProjectTarget(projectPath)
+	}
+
+	projects, err := filepath.Glob(filepath.Join(projectPath, "*.uproject"))
 	if err != nil {
-		return errors.Wrap(err, "failed to get unreal integration version")
+		return UnrealTarget{}, errors.Wrap(err, "failed to search for project file")
+	}
+	if len(projects) > 1 {
+		return UnrealTarget{}, errors.New("found more than one .uproject in " + projectPath)
+	}
+	if len(projects) == 1 {
+		return NewProjectTarget(projects[0])
 	}
 
-	versionInfo, err := ueIntegrationVersion.GetInfo()
+	target, err := NewEngineTarget(projectPath, pluginsDir)
 	if err != nil {
-		return errors.Wrap(err, "failed to get wwise manifest")
+		return UnrealTarget{}, errors.New(projectPath + " is neither an Unreal Engine project nor an engine")
 	}
+	return target, nil
+}
 
-	// Get UE version from project file
-	engineRoot, err := unrealengine.GetEngineRootFromProject(uprojectFilePath)
+func (i *UnrealIntegrator) EngineVersion(target IntegrationTarget) (string, error) {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return "", err
+	}
+	engineVersion, err := unrealTarget.GetEngineVersion()
 	if err != nil {
-		return errors.Wrap(err, "failed to get engine root")
+		return "", err
 	}
+	return engineVersion.String(), nil
+}
 
-	engineBuild, err := unrealengine.GetEngineVersionData(engineRoot)
+func (i *UnrealIntegrator) NormalizeOptions(options IntegrationOptions) (IntegrationOptions, error) {
+	configurations, err := normalizeConfigurations(options.Configurations)
 	if err != nil {
-		return errors.Wrap(err, "failed to get engine build")
+		return IntegrationOptions{}, err
 	}
+	options.Configurations = configurations
+	return options, nil
+}
 
-	wwiseUEDeploymentPlatform := fmt.Sprintf("UE%d%d", engineBuild.MajorVersion, engineBuild.MinorVersion)
+func (i *UnrealIntegrator) CheckCompatibility(target IntegrationTarget, versionInfo product.ProductVersionInfo, wwiseClient *client.WwiseClient) error {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return err
+	}
+	engineVersion, err := unrealTarget.GetEngineVersion()
+	if err != nil {
+		return err
+	}
+	return checkUnrealCompatibility(versionInfo, engineVersion, wwiseClient)
+}
+
+func (i *UnrealIntegrator) IntegrationFiles(target IntegrationTarget, versionInfo product.ProductVersionInfo, options IntegrationOptions) ([]product.File, error) {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePlatforms(versionInfo.ProductDependentData, options.Platforms); err != nil {
+		return nil, err
+	}
+
+	engineVersion, err := unrealTarget.GetEngineVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	wwiseUEDeploymentPlatform, err := UnrealDeploymentPlatform(engineVersion, &versionInfo)
+	if err != nil {
+		return nil, err
+	}
 
 	integrationFiles := versionInfo.FindFilesByGroups([]product.GroupFilter{
 		{GroupID: "DeploymentPlatforms", GroupValues: []string{wwiseUEDeploymentPlatform}},
@@ -54,129 +409,328 @@ func IntegrateWwiseUnreal(uprojectFilePath string, integrationVersion string, ww
 	})
 
 	if len(integrationFiles) == 0 {
-		return errors.New("failed to find integration file")
+		return nil, errors.New("failed to find integration file for " + wwiseUEDeploymentPlatform)
 	}
 
 	if len(integrationFiles) > 1 {
-		return errors.New("found more than one integration file")
+		return nil, errors.New("found more than one integration file")
 	}
 
-	err = ueIntegrationVersion.DownloadOrCache(integrationFiles[0])
+	return integrationFiles, nil
+}
+
+// InstallFiles installs the directories of the downloaded integration to the plugins directory as they are.
+func (i *UnrealIntegrator) InstallFiles(target IntegrationTarget, versionInfo product.ProductVersionInfo, integrationDir string, options IntegrationOptions) (string, []install.File, error) {
+	files, err := listIntegrationFiles(integrationDir)
 	if err != nil {
-		return errors.Wrap(err, "failed to download integration file")
+		return "", nil, err
+	}
+	// Projects often carry patches on the integration sources, keep them mergeable across upgrades
+	for n := range files {
+		files[n].Mergeable = true
 	}
+	return target.PluginsDir(), files, nil
+}
 
-	wwiseSDKVersion := fmt.Sprintf("%d.%d.%d.%d", versionInfo.Version.Year, versionInfo.Version.Major, versionInfo.Version.Minor, versionInfo.ProductDependentData.WwiseSdkBuild)
+func (i *UnrealIntegrator) SDKPlatforms(target IntegrationTarget, versionInfo product.ProductVersionInfo, options IntegrationOptions) ([]string, error) {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	engineVersion, err := unrealTarget.GetEngineVersion()
+	if err != nil {
+		return nil, err
+	}
+	return sdkDownloadPlatforms(versionInfo.ProductDependentData, engineVersion, options.Platforms)
+}
 
-	sdkProduct := product.NewWwiseProduct(wwiseClient, "wwise")
-	sdkProductVersion, err := sdkProduct.GetVersion(fmt.Sprintf("wwise.%s", wwiseSDKVersion))
+// SDKAssets installs the SDK folders listed by the integration to Wwise/ThirdParty.
+func (i *UnrealIntegrator) SDKAssets(target IntegrationTarget, versionInfo product.ProductVersionInfo, sdkDir string, options IntegrationOptions) ([]SDKAsset, error) {
+	unrealTarget, err := asUnrealTarget(target)
 	if err != nil {
-		return errors.Wrap(err, "failed to get sdk version")
+		return nil, err
+	}
+	engineVersion, err := unrealTarget.GetEngineVersion()
+	if err != nil {
+		return nil, err
 	}
 
-	type SDKIntegrationAsset struct {
-		Source      string
-		Destination string
+	thirdPartyAsset := func(source string, destination string, fileMatchExpression string) SDKAsset {
+		return SDKAsset{
+			Source:              source,
+			Destination:         path.Join("Wwise", "ThirdParty", destination),
+			FileMatchExpression: fileMatchExpression,
+		}
 	}
 
-	sdkAssets := []SDKIntegrationAsset{}
+	sdkAssets := []SDKAsset{}
 
 	if versionInfo.ProductDependentData.PlatformFolders != nil {
 		for _, folder := range versionInfo.ProductDependentData.PlatformFolders.Mandatory {
-			if _, err := os.Stat(filepath.Join(sdkProductVersion.Dir, "SDK", folder)); os.IsNotExist(err) {
-				return errors.New("failed to find mandatory folder: " + folder)
+			if _, err := os.Stat(filepath.Join(sdkDir, folder)); os.IsNotExist(err) {
+				return nil, errors.New("failed to find mandatory folder: " + folder)
 			}
-			sdkAssets = append(sdkAssets, SDKIntegrationAsset{
-				Source:      folder,
-				Destination: folder,
-			})
+			sdkAssets = append(sdkAssets, thirdPartyAsset(folder, folder, ""))
 		}
 
 		for _, folder := range versionInfo.ProductDependentData.PlatformFolders.Optional {
-			if _, err := os.Stat(filepath.Join(sdkProductVersion.Dir, "SDK", folder)); !os.IsNotExist(err) {
-				sdkAssets = append(sdkAssets, SDKIntegrationAsset{
-					Source:      folder,
-					Destination: folder,
-				})
+			if len(options.Platforms) > 0 && !matchesPlatform(folder, options.Platforms) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(sdkDir, folder)); !os.IsNotExist(err) {
+				sdkAssets = append(sdkAssets, thirdPartyAsset(folder, folder, ""))
 			}
 		}
 	} else if versionInfo.ProductDependentData.SdkPlatformFolders != nil {
-		for _, platformInfos := range *versionInfo.ProductDependentData.SdkPlatformFolders {
-			for _, platformInfo := range platformInfos {
-				// TODO: Use FileMatchExpression, but for now it seems like it's always "*"
-
-				if platformInfo.SinceEngine != nil {
-					major, err := strconv.Atoi(platformInfo.SinceEngine.Major)
-					if err != nil {
-						return errors.Wrap(err, "failed to parse major version")
-					}
-					minor, err := strconv.Atoi(platformInfo.SinceEngine.Minor)
-					if err != nil {
-						return errors.Wrap(err, "failed to parse minor version")
-					}
-					if engineBuild.MajorVersion < major || (engineBuild.MajorVersion == major && engineBuild.MinorVersion < minor) {
-						continue
-					}
-				}
+		var platforms []string
+		for platform := range *versionInfo.ProductDependentData.SdkPlatformFolders {
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
 
-				if platformInfo.UntilEngine != nil {
-					major, err := strconv.Atoi(platformInfo.UntilEngine.Major)
-					if err != nil {
-						return errors.Wrap(err, "failed to parse major version")
-					}
-					minor, err := strconv.Atoi(platformInfo.UntilEngine.Minor)
-					if err != nil {
-						return errors.Wrap(err, "failed to parse minor version")
-					}
-					if engineBuild.MajorVersion > major || (engineBuild.MajorVersion == major && engineBuild.MinorVersion > minor) {
-						continue
-					}
+		for _, platform := range platforms {
+			for _, platformInfo := range (*versionInfo.ProductDependentData.SdkPlatformFolders)[platform] {
+				applies, err := appliesToEngine(platformInfo, engineVersion)
+				if err != nil {
+					return nil, err
+				}
+				if !applies {
+					continue
 				}
 
 				if platformInfo.Optional {
-					if _, err := os.Stat(filepath.Join(sdkProductVersion.Dir, "SDK", platformInfo.Source)); !os.IsNotExist(err) {
-						sdkAssets = append(sdkAssets, SDKIntegrationAsset{
-							Source:      platformInfo.Source,
-							Destination: platformInfo.Destination,
-						})
+					if !isPlatformSelected(platform, options.Platforms) {
+						continue
+					}
+					if _, err := os.Stat(filepath.Join(sdkDir, platformInfo.Source)); !os.IsNotExist(err) {
+						sdkAssets = append(sdkAssets, thirdPartyAsset(platformInfo.Source, platformInfo.Destination, platformInfo.FileMatchExpression))
 					}
 				} else {
-					if _, err := os.Stat(filepath.Join(sdkProductVersion.Dir, "SDK", platformInfo.Source)); os.IsNotExist(err) {
-						return errors.New("failed to find mandatory folder: " + platformInfo.Source)
+					if _, err := os.Stat(filepath.Join(sdkDir, platformInfo.Source)); os.IsNotExist(err) {
+						return nil, errors.New("failed to find mandatory folder: " + platformInfo.Source)
 					}
-					sdkAssets = append(sdkAssets, SDKIntegrationAsset{
-						Source:      platformInfo.Source,
-						Destination: platformInfo.Destination,
-					})
+					sdkAssets = append(sdkAssets, thirdPartyAsset(platformInfo.Source, platformInfo.Destination, platformInfo.FileMatchExpression))
 				}
 			}
 		}
 	} else {
-		return errors.New("failed to find platform folders")
+		return nil, errors.New("failed to find platform folders")
 	}
 
-	integrationAssets, err := os.ReadDir(ueIntegrationVersion.Dir)
+	return sdkAssets, nil
+}
+
+// Edits enables the plugins of the integration in the project file, and writes the Wwise project settings if one is set.
+func (i *UnrealIntegrator) Edits(target IntegrationTarget, versionInfo product.ProductVersionInfo, files []install.File, options IntegrationOptions) ([]install.FileEdit, error) {
+	unrealTarget, err := asUnrealTarget(target)
 	if err != nil {
-		return errors.Wrap(err, "failed to read integration cache path")
+		return nil, err
 	}
 
-	projectRoot := filepath.Dir(uprojectFilePath)
-	for _, entry := range integrationAssets {
-		if entry.IsDir() {
-			err = cp.Copy(filepath.Join(ueIntegrationVersion.Dir, entry.Name()), filepath.Join(projectRoot, "Plugins", entry.Name()))
-			if err != nil {
-				return errors.Wrapf(err, "failed to copy integration asset: %s", entry.Name())
-			}
+	pluginPlatforms := settingList(options.Settings[unrealSettingPluginPlatforms])
+	wwiseProject := options.Settings[unrealSettingWwiseProject]
+
+	if unrealTarget.IsEngine() {
+		if wwiseProject != "" {
+			return nil, errors.New("the Wwise project settings can only be configured for projects")
+		}
+		return nil, nil
+	}
+
+	engineVersion, err := unrealTarget.GetEngineVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []install.FileEdit
+	edit, err := enableProjectPluginsEdit(unrealTarget.Project, files, pluginPlatforms, engineVersion)
+	if err != nil {
+		return nil, err
+	}
+	if edit != nil {
+		edits = append(edits, *edit)
+	}
+
+	if wwiseProject != "" {
+		settingsEdits, err := unrealSettingsEdits(unrealTarget.Project, UnrealSettings{
+			WwiseProject:       wwiseProject,
+			SoundBanksDir:      options.Settings[unrealSettingSoundBanksDir],
+			Platforms:          pluginPlatforms,
+			IntegrationVersion: versionInfo.Version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, settingsEdits...)
+	}
+
+	return edits, nil
+}
+
+func (i *UnrealIntegrator) Installed(target IntegrationTarget) (*install.Manifest, error) {
+	return installedUnreal(target.PluginsDir())
+}
+
+// Verify checks the integration against what its version installs, and, for projects, the config files against
+// the settings recorded when integrating or configuring.
+func (i *UnrealIntegrator) Verify(target IntegrationTarget, integrationVersion string, wwiseClient *client.WwiseClient) (*install.Verification, error) {
+	verification, err := VerifyIntegration(i, target, integrationVersion, wwiseClient)
+	if err != nil {
+		return nil, err
+	}
+
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	if unrealTarget.IsEngine() {
+		return verification, nil
+	}
+
+	installed, err := install.ReadManifest(target.PluginsDir())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read install manifest")
+	}
+	if installed == nil {
+		return verification, nil
+	}
+	drift, err := unrealSettingsDrift(unrealTarget, installed)
+	if err != nil {
+		return nil, err
+	}
+	planned := make(map[string]bool)
+	for _, editPath := range verification.Edits {
+		planned[editPath] = true
+	}
+	for _, configPath := range drift {
+		if !planned[configPath] {
+			verification.Edits = append(verification.Edits, configPath)
+		}
+	}
+	return verification, nil
+}
+
+// Adopt downloads the integration version the Wwise plugin declares, and claims the files it shipped that are still in the plugins directory.
+// They are recorded with the hash they were shipped with, so that the ones modified since are kept.
+func (i *UnrealIntegrator) Adopt(target IntegrationTarget, installed *install.Manifest, wwiseClient *client.WwiseClient) error {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return err
+	}
+
+	refuse := func(err error) error {
+		return errors.Wrapf(err, "failed to find the files of integration %s, which was not installed by wwise-cli, remove it before integrating", installed.IntegrationVersion)
+	}
+
+	if installed.IntegrationVersion == "" {
+		return refuse(errors.New("Wwise.uplugin has no VersionName"))
+	}
+
+	integrationVersion, err := product.NewWwiseProduct(wwiseClient, i.Product()).GetVersion(installed.IntegrationVersion)
+	if err != nil {
+		return refuse(err)
+	}
+
+	versionInfo, err := integrationVersion.GetInfo()
+	if err != nil {
+		return refuse(err)
+	}
+
+	integrationFiles, err := i.IntegrationFiles(unrealTarget, versionInfo, IntegrationOptions{})
+	if err != nil {
+		return refuse(err)
+	}
+	for _, file := range integrationFiles {
+		if err := integrationVersion.DownloadOrCache(file); err != nil {
+			return refuse(err)
 		}
 	}
 
-	mainWwisePlugin := filepath.Join(projectRoot, "Plugins", "Wwise")
-	for _, sdkAsset := range sdkAssets {
-		err = cp.Copy(filepath.Join(sdkProductVersion.Dir, "SDK", sdkAsset.Source), filepath.Join(mainWwisePlugin, "ThirdParty", sdkAsset.Destination))
+	files, err := listIntegrationFiles(integrationVersion.Dir)
+	if err != nil {
+		return refuse(err)
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(target.PluginsDir(), filepath.FromSlash(file.Path))); err != nil {
+			continue
+		}
+		hash, err := install.HashFile(file.Source)
 		if err != nil {
-			return errors.Wrap(err, "failed to copy third party files")
+			return errors.Wrapf(err, "failed to hash %s", file.Source)
 		}
+		installed.Files[file.Path] = hash
 	}
+	installed.IntegrationVersion = integrationVersion.VersionId
+	installed.SdkVersion = sdkVersionForIntegration(versionInfo)
 
 	return nil
 }
+
+func (i *UnrealIntegrator) CheckOutdated(target IntegrationTarget, wwiseClient *client.WwiseClient) (*OutdatedReport, error) {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	return checkOutdatedUnreal(unrealTarget, wwiseClient)
+}
+
+// SettingsUpToDate reports whether the config files of the project have the Wwise project settings, if one is set.
+func (i *UnrealIntegrator) SettingsUpToDate(target IntegrationTarget, settings map[string]string) (bool, error) {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return false, err
+	}
+	if unrealTarget.IsEngine() || settings[unrealSettingWwiseProject] == "" {
+		return true, nil
+	}
+	edits, err := configureEdits(unrealTarget, UnrealSettings{
+		WwiseProject:  settings[unrealSettingWwiseProject],
+		SoundBanksDir: settings[unrealSettingSoundBanksDir],
+		Platforms:     settingList(settings[unrealSettingPluginPlatforms]),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check wwise settings")
+	}
+	return len(edits) == 0, nil
+}
+
+func (i *UnrealIntegrator) Uninstall(target IntegrationTarget, force bool) (*install.UninstallResult, error) {
+	unrealTarget, err := asUnrealTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	return uninstallUnreal(unrealTarget, force)
+}
+
+// enableProjectPluginsEdit returns the edit of the project file enabling the plugins of the integration, or nil if they are enabled already.
+func enableProjectPluginsEdit(uprojectFilePath string, files []install.File, platforms []string, engineVersion unrealengine.EngineVersion) (*install.FileEdit, error) {
+	var pluginNames []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		dir, name := path.Split(file.Path)
+		if path.Ext(name) == ".uplugin" && strings.Count(dir, "/") == 1 && !seen[file.Path] {
+			seen[file.Path] = true
+			pluginNames = append(pluginNames, strings.TrimSuffix(name, ".uplugin"))
+		}
+	}
+	sort.Strings(pluginNames)
+
+	content, changes, err := unrealengine.EnableProjectPlugins(uprojectFilePath, pluginNames, platforms, engineVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to enable plugins in project")
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	edit, err := install.NewFileEdit(uprojectFilePath, content, "enable "+strings.Join(pluginNames, ", "))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan project file edit")
+	}
+	edit.Changes, err = json.Marshal(unrealEditChanges{Plugins: changes})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal project file changes")
+	}
+	return &edit, nil
+}
@@ -0,0 +1,94 @@
+package wwise
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mircearoata/wwise-cli/lib/unrealengine"
+	"github.com/pkg/errors"
+)
+
+// UnrealTarget is where an Unreal integration is installed: the Plugins directory of a project,
+// or a plugins directory of an engine, to be shared by every project using that engine.
+type UnrealTarget struct {
+	// Project is the .uproject file, for project targets.
+	Project string
+	// EngineRoot is the engine directory. For project targets, it overrides the engine association of the project.
+	EngineRoot string
+	// EngineVersion overrides the version read from the engine, as major.minor[.patch].
+	EngineVersion string
+
+	pluginsDir string
+}
+
+func NewProjectTarget(uprojectFilePath string) (UnrealTarget, error) {
+	if filepath.Ext(uprojectFilePath) != ".uproject" {
+		return UnrealTarget{}, errors.New("invalid project path: " + uprojectFilePath)
+	}
+
+	if _, err := os.Stat(uprojectFilePath); os.IsNotExist(err) {
+		return UnrealTarget{}, errors.Wrap(err, "project path does not exist")
+	}
+
+	return UnrealTarget{
+		Project:    uprojectFilePath,
+		pluginsDir: filepath.Join(filepath.Dir(uprojectFilePath), "Plugins"),
+	}, nil
+}
+
+// NewEngineTarget installs to pluginsDir, relative to the engine root, or to Engine/Plugins/Marketplace if it is empty.
+func NewEngineTarget(engineRoot string, pluginsDir string) (UnrealTarget, error) {
+	if _, err := os.Stat(filepath.Join(engineRoot, "Engine")); err != nil {
+		return UnrealTarget{}, errors.Wrap(err, "invalid engine root")
+	}
+
+	if pluginsDir == "" {
+		pluginsDir = filepath.Join("Engine", "Plugins", "Marketplace")
+	}
+	if !filepath.IsAbs(pluginsDir) {
+		pluginsDir = filepath.Join(engineRoot, pluginsDir)
+	}
+
+	return UnrealTarget{
+		EngineRoot: engineRoot,
+		pluginsDir: pluginsDir,
+	}, nil
+}
+
+func (t UnrealTarget) IsEngine() bool {
+	return t.Project == ""
+}
+
+func (t UnrealTarget) PluginsDir() string {
+	return t.pluginsDir
+}
+
+func (t UnrealTarget) GetEngineRoot() (string, error) {
+	if t.EngineRoot != "" {
+		return t.EngineRoot, nil
+	}
+
+	engineRoot, err := unrealengine.GetEngineRootFromProject(t.Project)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get engine root")
+	}
+	return engineRoot, nil
+}
+
+// GetEngineVersion returns the version of the engine the integration is installed for, and where it was read from.
+func (t UnrealTarget) GetEngineVersion() (unrealengine.EngineVersion, error) {
+	if t.EngineVersion != "" {
+		return unrealengine.ParseEngineVersion(t.EngineVersion)
+	}
+
+	engineRoot, err := t.GetEngineRoot()
+	if err != nil {
+		return unrealengine.EngineVersion{}, err
+	}
+
+	engineVersion, err := unrealengine.GetEngineVersion(engineRoot)
+	if err != nil {
+		return unrealengine.EngineVersion{}, errors.Wrap(err, "failed to get engine version")
+	}
+	return engineVersion, nil
+}
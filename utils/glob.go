@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Glob matches relative paths the way the Wwise Launcher matches file expressions:
+// `*` and `?` do not cross path separators, `**` matches any number of directories,
+// `[...]` is a character class, and `{a,b}` matches either alternative.
+// An expression can list several patterns separated by `;`, and matches if any of them does.
+// Matching ignores case, and both `/` and `\` separate directories, in patterns as well as in paths.
+// A pattern without a separator is matched against the file name only.
+type Glob struct {
+	patterns []globPattern
+}
+
+type globPattern struct {
+	matchBase bool
+	re        *regexp.Regexp
+}
+
+func CompileGlob(expression string) (*Glob, error) {
+	glob := &Glob{}
+	for _, pattern := range strings.Split(expression, ";") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		compiled, err := compileGlobPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		glob.patterns = append(glob.patterns, compiled)
+	}
+
+	if len(glob.patterns) == 0 {
+		compiled, err := compileGlobPattern("*")
+		if err != nil {
+			return nil, err
+		}
+		glob.patterns = append(glob.patterns, compiled)
+	}
+
+	return glob, nil
+}
+
+func compileGlobPattern(pattern string) (globPattern, error) {
+	pattern = strings.ReplaceAll(pattern, `\`, "/")
+
+	var sb strings.Builder
+	sb.WriteString("(?i)^")
+	braceDepth := 0
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "**/" also matches no directory at all
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end == -1 {
+				return globPattern{}, errors.New("unterminated character class in " + pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			sb.WriteString("[")
+			if strings.HasPrefix(class, "!") || strings.HasPrefix(class, "^") {
+				// A negated class does not cross path separators either
+				sb.WriteString("^/")
+				class = class[1:]
+			}
+			sb.WriteString(regexp.QuoteMeta(class) + "]")
+			i += end + 1
+		case '{':
+			braceDepth++
+			sb.WriteString("(?:")
+		case '}':
+			if braceDepth == 0 {
+				sb.WriteString(regexp.QuoteMeta("}"))
+				continue
+			}
+			braceDepth--
+			sb.WriteString(")")
+		case ',':
+			if braceDepth == 0 {
+				sb.WriteString(",")
+				continue
+			}
+			sb.WriteString("|")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if braceDepth != 0 {
+		return globPattern{}, errors.New("unterminated brace expression in " + pattern)
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return globPattern{}, errors.Wrapf(err, "invalid file expression %s", pattern)
+	}
+
+	return globPattern{
+		matchBase: !strings.Contains(pattern, "/"),
+		re:        re,
+	}, nil
+}
+
+// Match reports whether the path, relative to the folder the expression applies to, matches the expression.
+func (g *Glob) Match(relPath string) bool {
+	relPath = strings.ReplaceAll(filepath.ToSlash(relPath), `\`, "/")
+	for _, pattern := range g.patterns {
+		if pattern.matchBase {
+			if pattern.re.MatchString(relPath[strings.LastIndex(relPath, "/")+1:]) {
+				return true
+			}
+		} else if pattern.re.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
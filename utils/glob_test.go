@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		expression string
+		path       string
+		want       bool
+	}{
+		// An empty expression matches everything, like "*"
+		{"", "bin/AkSoundEngine.dll", true},
+		{"*", "bin/AkSoundEngine.dll", true},
+
+		// Patterns without a separator match the file name in any directory
+		{"*.dll", "AkSoundEngine.dll", true},
+		{"*.dll", "bin/AkSoundEngine.dll", true},
+		{"*.dll", "bin/AkSoundEngine.pdb", false},
+		{"*.dll", "bin/AkSoundEngine.dll.meta", false},
+
+		// * and ? do not cross directories, ** does
+		{"bin/*.dll", "bin/AkSoundEngine.dll", true},
+		{"bin/*.dll", "bin/sub/AkSoundEngine.dll", false},
+		{"lib/?.a", "lib/a.a", true},
+		{"lib/?.a", "lib/ab.a", false},
+		{"lib/?/x.a", "lib/a/x.a", true},
+		{"lib/*", "lib/a/x.a", false},
+		{"lib/**", "lib/a/x.a", true},
+		{"**/*.lib", "x.lib", true},
+		{"**/*.lib", "Release/lib/x.lib", true},
+		{"Release/**/*.lib", "Release/x.lib", true},
+		{"Release/**/*.lib", "Release/lib/x.lib", true},
+		{"Release/**/*.lib", "Profile/lib/x.lib", false},
+
+		// Case is ignored, in patterns and in paths
+		{"*.DLL", "bin/AkSoundEngine.dll", true},
+		{"Release/*.lib", "release/x.LIB", true},
+
+		// Several patterns are separated by ;
+		{"*.dll;*.pdb", "bin/AkSoundEngine.pdb", true},
+		{"*.dll; *.pdb", "bin/AkSoundEngine.pdb", true},
+		{"*.dll;*.pdb", "bin/AkSoundEngine.lib", false},
+		{"*.dll;;", "bin/AkSoundEngine.dll", true},
+
+		// Both separators split directories, in patterns and in paths
+		{`bin\*.dll`, "bin/AkSoundEngine.dll", true},
+		{"bin/*.dll", `bin\AkSoundEngine.dll`, true},
+		{`bin\*.dll`, "bin/sub/AkSoundEngine.dll", false},
+
+		// Character classes and alternatives
+		{"lib[0-9].a", "lib1.a", true},
+		{"lib[0-9].a", "libx.a", false},
+		{"lib[!0-9].a", "libx.a", true},
+		{"lib[!0-9].a", "lib1.a", false},
+		{"*.{dll,so}", "bin/libAk.so", true},
+		{"*.{dll,so}", "bin/libAk.a", false},
+		{"{Debug,Release}/**", "Release/lib/x.lib", true},
+		{"{Debug,Release}/**", "Profile/lib/x.lib", false},
+
+		// Regexp characters are literal
+		{"a+b.(1)", "a+b.(1)", true},
+		{"a+b.(1)", "aab.(1)", false},
+	}
+
+	for _, test := range tests {
+		glob, err := CompileGlob(test.expression)
+		if err != nil {
+			t.Errorf("CompileGlob(%q): %v", test.expression, err)
+			continue
+		}
+		if got := glob.Match(test.path); got != test.want {
+			t.Errorf("CompileGlob(%q).Match(%q) = %v, want %v", test.expression, test.path, got, test.want)
+		}
+	}
+}
+
+func TestCompileGlobErrors(t *testing.T) {
+	for _, expression := range []string{"lib[0-9.a", "*.{dll,so", "*.dll;lib[", "{a,{b}"} {
+		if _, err := CompileGlob(expression); err == nil {
+			t.Errorf("CompileGlob(%q) succeeded, want an error", expression)
+		}
+	}
+}
+
+// TestGlobMatchesPathMatch checks every pattern against every path, not just chosen pairs:
+// apart from case and the file name matching of patterns without a separator,
+// `*`, `?` and character classes behave like path.Match.
+func TestGlobMatchesPathMatch(t *testing.T) {
+	patterns := []string{
+		"*", "?", "*.*", "*.lib", "*.dll", "Ak*", "*Sound*", "lib?*.a", "*.[ad]*", "[a-l]*", "[^a-l]*", "lib[0-9].a",
+		"bin/*", "*/*", "*/lib/*.lib", "Profile/*/*.lib", "*/bin/?*.dll", "Release/lib/AkSoundEngine.lib", "?/*",
+	}
+	paths := []string{
+		"AkSoundEngine.lib", "lib1.a", "liba.a", "libAk.so", "x.dll", "a", ".lib",
+		"bin/AkSoundEngine.dll", "bin/sub/AkSoundEngine.dll", "Profile/lib/AkSoundEngine.lib", "Release/lib/AkSoundEngine.LIB",
+		"Profile/bin/AkVorbisDecoder.dll", "Profile/bin/sub/AkVorbisDecoder.dll", "a/b", "ab/c", "Debug/lib/lib1.a",
+	}
+
+	for _, pattern := range patterns {
+		glob, err := CompileGlob(pattern)
+		if err != nil {
+			t.Fatalf("CompileGlob(%q): %v", pattern, err)
+		}
+		for _, p := range paths {
+			subject := strings.ToLower(p)
+			if !strings.Contains(pattern, "/") {
+				subject = path.Base(subject)
+			}
+			want, err := path.Match(strings.ToLower(pattern), subject)
+			if err != nil {
+				t.Fatalf("path.Match(%q): %v", pattern, err)
+			}
+			if got := glob.Match(p); got != want {
+				t.Errorf("CompileGlob(%q).Match(%q) = %v, path.Match gives %v", pattern, p, got, want)
+			}
+		}
+	}
+}